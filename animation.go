@@ -0,0 +1,91 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"math"
+	"os"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// SnapshotInterval is the number of training iterations between the
+	// snapshots of the embedding tracked by Neural
+	SnapshotInterval = 50
+	// animationSize is the width and the height of the animation frames
+	animationSize = 256
+)
+
+// AnimateEmbeddings writes a gif with a frame for each snapshot of the
+// embedding showing the cities projected onto the first two principal
+// components, each city has its own color
+func AnimateEmbeddings(snapshots [][]float64, Scale int, path string) error {
+	palette := color.Palette{color.White}
+	for i := 0; i < Size; i++ {
+		palette = append(palette, hue(float64(i)/Size))
+	}
+	projections := make([]*mat.Dense, len(snapshots))
+	minX, minY, maxX, maxY := math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64
+	for i, snapshot := range snapshots {
+		embedding := mat.NewDense(Size, Scale*Size, nil)
+		for city := 0; city < Size; city++ {
+			for k := 0; k < Scale*Size; k++ {
+				embedding.Set(city, k, snapshot[city+k*Size])
+			}
+		}
+		projection, err := project(embedding)
+		if err != nil {
+			return err
+		}
+		projections[i] = projection
+		for city := 0; city < Size; city++ {
+			x, y := projection.At(city, 0), projection.At(city, 1)
+			minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+			minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+		}
+	}
+	scale := func(v, min, max float64) int {
+		if max == min {
+			return animationSize / 2
+		}
+		return 8 + int((v-min)/(max-min)*(animationSize-17))
+	}
+
+	animation := &gif.GIF{}
+	for _, projection := range projections {
+		frame := image.NewPaletted(image.Rect(0, 0, animationSize, animationSize), palette)
+		for city := 0; city < Size; city++ {
+			x := scale(projection.At(city, 0), minX, maxX)
+			y := animationSize - 1 - scale(projection.At(city, 1), minY, maxY)
+			for dx := -3; dx <= 3; dx++ {
+				for dy := -3; dy <= 3; dy++ {
+					frame.SetColorIndex(x+dx, y+dy, uint8(city+1))
+				}
+			}
+		}
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, 10)
+	}
+
+	output, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+	return gif.EncodeAll(output, animation)
+}
+
+// hue is the fully saturated color with the hue h in [0, 1)
+func hue(h float64) color.Color {
+	channel := func(offset float64) uint8 {
+		v := math.Abs(math.Mod(h*6+offset, 6)-3) - 1
+		return uint8(255 * math.Max(0, math.Min(1, v)))
+	}
+	return color.RGBA{R: channel(0), G: channel(4), B: channel(2), A: 255}
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"image/gif"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnimateEmbeddings(t *testing.T) {
+	// the training converges after more than 51 iterations
+	rand.Seed(1)
+	for _, iterations := range []int{1, 50, 51} {
+		_, snapshots, _ := neuralTrain(canonical, 4, iterations, NeuralOptions{TrackEmbeddings: true, MaxGradNorm: DefaultMaxGradNorm})
+		if expected := (iterations + SnapshotInterval - 1) / SnapshotInterval; len(snapshots) != expected {
+			t.Fatalf("Expected %d snapshots for %d iterations, got %d", expected, iterations, len(snapshots))
+		}
+	}
+	_, snapshots, _ := neuralTrain(canonical, 4, 1024, NeuralOptions{TrackEmbeddings: true, MaxGradNorm: DefaultMaxGradNorm})
+	path := filepath.Join(t.TempDir(), "embedding_animation.gif")
+	if err := AnimateEmbeddings(snapshots, 4, path); err != nil {
+		t.Fatal(err)
+	}
+	input, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer input.Close()
+	animation, err := gif.DecodeAll(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(animation.Image) != len(snapshots) {
+		t.Errorf("Expected %d frames, got %d", len(snapshots), len(animation.Image))
+	}
+}
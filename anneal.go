@@ -0,0 +1,188 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+)
+
+// SAOptions are the options for simulated annealing
+type SAOptions struct {
+	// Iterations is the maximum number of moves to try
+	Iterations int
+	// Temperature is the initial temperature
+	Temperature float64
+	// Cooling is the factor the temperature is multiplied by each iteration
+	Cooling float64
+	// Target stops the search once the best tour costs at most Target, zero
+	// disables it
+	Target float64
+	// Seed seeds the random number generator
+	Seed int64
+	// InitMethod is the InitialTour method used when no tour is given
+	InitMethod string
+	// CheckpointInterval is the number of iterations between checkpoints,
+	// zero disables checkpoints
+	CheckpointInterval int
+	// CheckpointPath is the file the checkpoints are written to
+	CheckpointPath string
+	// OnImprove is called with the starting tour and each better tour found,
+	// if it isn't nil
+	OnImprove func(Tour) `json:"-"`
+}
+
+// SimulatedAnnealing improves the tour with random 2-opt moves, accepting a
+// worse tour with probability exp(-delta/temperature). If the tour is nil the
+// search starts from the InitialTour of opts.InitMethod. Every
+// opts.CheckpointInterval iterations the state of the search is written to
+// opts.CheckpointPath, see ResumeSimulatedAnnealing.
+func SimulatedAnnealing(dist []float64, size int, tour []int, opts SAOptions) Tour {
+	return simulatedAnnealing(dist, size, tour, opts, hooks{})
+}
+
+// simulatedAnnealing is SimulatedAnnealing measuring its moves with the probe
+// of the hooks
+func simulatedAnnealing(dist []float64, size int, tour []int, opts SAOptions, h hooks) Tour {
+	source := newCountingSource(opts.Seed, 0)
+	if tour == nil {
+		tour = InitialTour(dist, size, opts.InitMethod, rand.New(source)).Route
+	}
+	cost := TourCost(dist, size, tour)
+	return anneal(dist, size, Checkpoint{
+		Options:     opts,
+		Best:        tourJSON{Cost: cost, Route: append([]int{}, tour...)},
+		Current:     tourJSON{Cost: cost, Route: append([]int{}, tour...)},
+		Temperature: opts.Temperature,
+	}, source, h)
+}
+
+// anneal runs simulated annealing from the state, source is the random
+// number source of the state
+func anneal(dist []float64, size int, state Checkpoint, source *countingSource, h hooks) Tour {
+	p := h.probe
+	opts := state.Options
+	rng := rand.New(source)
+	route := append([]int{}, state.Current.Route...)
+	cost := state.Current.Cost
+	best := Tour{Cost: state.Best.Cost, Route: append([]int{}, state.Best.Route...), Iterations: state.Iterations}
+	if size < 4 {
+		return best
+	}
+	if opts.OnImprove != nil {
+		opts.OnImprove(Tour{Cost: best.Cost, Route: append([]int{}, best.Route...), Iterations: best.Iterations})
+	}
+	temperature := state.Temperature
+	for best.Iterations < opts.Iterations {
+		if opts.Target > 0 && best.Cost <= opts.Target {
+			break
+		}
+		best.Iterations++
+		i := 1 + rng.Intn(size-1)
+		j := 1 + rng.Intn(size-2)
+		if j >= i {
+			j++
+		} else {
+			i, j = j, i
+		}
+		move := p.start(ProfileMove)
+		p.reverse(route, i, j)
+		c := p.tourCost(dist, size, route)
+		if delta := c - cost; delta <= 0 || rng.Float64() < math.Exp(-delta/temperature) {
+			cost = c
+			if cost < best.Cost {
+				best.Cost = cost
+				copy(best.Route, route)
+				if opts.OnImprove != nil {
+					opts.OnImprove(Tour{Cost: best.Cost, Route: append([]int{}, best.Route...), Iterations: best.Iterations})
+				}
+			}
+		} else {
+			p.reverse(route, i, j)
+		}
+		p.end(ProfileMove, move)
+		temperature *= opts.Cooling
+		if opts.CheckpointInterval > 0 && best.Iterations%opts.CheckpointInterval == 0 {
+			err := WriteCheckpoint(opts.CheckpointPath, Checkpoint{
+				Options:     opts,
+				Iterations:  best.Iterations,
+				Best:        tourJSON{Cost: best.Cost, Route: best.Route},
+				Current:     tourJSON{Cost: cost, Route: route},
+				Temperature: temperature,
+				Draws:       source.draws,
+			})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+	return best
+}
+
+// StochasticTwoOptOptions are the options for StochasticTwoOpt
+type StochasticTwoOptOptions struct {
+	// Iterations is the number of moves to try
+	Iterations int
+	// Temperature is the initial temperature
+	Temperature float64
+	// Cooling is the factor the temperature is multiplied by each iteration
+	Cooling float64
+	// Seed seeds the random number generator
+	Seed int64
+}
+
+// StochasticTwoOpt makes random 2-opt moves from a random tour, accepting a
+// worse tour with probability exp(-delta/temperature). Unlike
+// SimulatedAnnealing the change in cost is computed from the two edges a move
+// replaces, so a move takes constant time but the distances must be
+// symmetric.
+func StochasticTwoOpt(dist []float64, size int, opts StochasticTwoOptOptions) Tour {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	route := InitialTour(dist, size, InitRandom, rng).Route
+	cost := TourCost(dist, size, route)
+	best := Tour{Cost: cost, Route: append([]int{}, route...)}
+	if size < 4 {
+		return best
+	}
+	temperature := opts.Temperature
+	for best.Iterations < opts.Iterations {
+		best.Iterations++
+		i := 1 + rng.Intn(size-1)
+		j := 1 + rng.Intn(size-2)
+		if j >= i {
+			j++
+		} else {
+			i, j = j, i
+		}
+		a, b, c, d := route[i-1], route[i], route[j], route[j+1]
+		delta := dist[a*size+c] + dist[b*size+d] - dist[a*size+b] - dist[c*size+d]
+		if delta <= 0 || rng.Float64() < math.Exp(-delta/temperature) {
+			reverse(route, i, j)
+			cost += delta
+			if cost < best.Cost {
+				// recompute the cost so the rounding errors of the deltas
+				// don't accumulate
+				cost = TourCost(dist, size, route)
+				best.Cost = cost
+				copy(best.Route, route)
+			}
+		}
+		temperature *= opts.Cooling
+	}
+	return best
+}
+
+// EigenSA anneals starting from the tour found by Eigen
+func EigenSA(dist []float64, size int, opts SAOptions) Tour {
+	_, _, route := EigenWithOptions(dist, EigenOptions{})
+	return SimulatedAnnealing(dist, size, route, opts)
+}
+
+// NNSA anneals starting from the nearest neighbor tour
+func NNSA(dist []float64, size int, opts SAOptions) Tour {
+	return SimulatedAnnealing(dist, size, nearestNeighbor(dist, size, 0).Route, opts)
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSimulatedAnnealing(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 8; i++ {
+		dist := randomInstance(rng, 8)
+		nn := nearestNeighbor(dist, 8, 0)
+		for _, tour := range []Tour{
+			NNSA(dist, 8, SAOptions{Iterations: 4096, Temperature: 50, Cooling: .999, Seed: 1}),
+			EigenSA(dist, 8, SAOptions{Iterations: 4096, Temperature: 50, Cooling: .999, Seed: 1}),
+		} {
+			if err := ValidateTour(tour.Route, 8); err != nil {
+				t.Fatal(err)
+			}
+			if c := TourCost(dist, 8, tour.Route); c != tour.Cost {
+				t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+			}
+		}
+		tour := SimulatedAnnealing(dist, 8, nn.Route, SAOptions{Iterations: 4096, Temperature: 50, Cooling: .999, Seed: 1, Target: nn.Cost})
+		if tour.Iterations != 0 {
+			t.Errorf("Expected to stop at the target, ran %d iterations", tour.Iterations)
+		}
+	}
+}
+
+// convergence is the mean number of iterations for an annealer to get within
+// 1% of the reference cost on 20 random 12 city instances, the reference cost
+// is from a long iterated local search
+func convergence(b *testing.B, anneal func(dist []float64, size int, opts SAOptions) Tour) {
+	rng := rand.New(rand.NewSource(1))
+	type instance struct {
+		dist   []float64
+		target float64
+	}
+	instances := make([]instance, 20)
+	for i := range instances {
+		dist := randomInstance(rng, 12)
+		reference := IteratedLocalSearch(dist, 12, ILSOptions{Iterations: 1000, Seed: 1})
+		instances[i] = instance{dist: dist, target: 1.01 * reference.Cost}
+	}
+	b.ResetTimer()
+	iterations := 0
+	for n := 0; n < b.N; n++ {
+		for _, inst := range instances {
+			tour := anneal(inst.dist, 12, SAOptions{
+				Iterations:  100000,
+				Temperature: 50,
+				Cooling:     .9999,
+				Target:      inst.target,
+				Seed:        int64(n),
+			})
+			iterations += tour.Iterations
+		}
+	}
+	b.ReportMetric(float64(iterations)/float64(b.N*len(instances)), "iterations/instance")
+}
+
+func BenchmarkEigenSA(b *testing.B) {
+	convergence(b, EigenSA)
+}
+
+func BenchmarkNNSA(b *testing.B) {
+	convergence(b, NNSA)
+}
+
+func TestStochasticTwoOpt(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 8; i++ {
+		dist := euclideanInstance(rng, 15)
+		tour := StochasticTwoOpt(dist, 15, StochasticTwoOptOptions{Iterations: 10000, Temperature: 1, Cooling: .999, Seed: 1})
+		if err := VerifyTour(dist, 15, tour); err != nil {
+			t.Fatal(err)
+		}
+		if nn := nearestNeighbor(dist, 15, 0); tour.Cost > 1.1*nn.Cost {
+			t.Errorf("Expected a cost close to nearest neighbor %f, got %f", nn.Cost, tour.Cost)
+		}
+	}
+}
+
+// annealing15 is the mean cost of an annealer after 10000 iterations on 20
+// random euclidean 15 city instances
+func annealing15(b *testing.B, anneal func(dist []float64, size int, seed int64) Tour) {
+	rng := rand.New(rand.NewSource(1))
+	instances := make([][]float64, 20)
+	for i := range instances {
+		instances[i] = euclideanInstance(rng, 15)
+	}
+	b.ResetTimer()
+	cost := 0.0
+	for n := 0; n < b.N; n++ {
+		for _, dist := range instances {
+			cost += anneal(dist, 15, int64(n)).Cost
+		}
+	}
+	b.ReportMetric(cost/float64(b.N*len(instances)), "cost/instance")
+}
+
+func BenchmarkStochasticTwoOpt(b *testing.B) {
+	annealing15(b, func(dist []float64, size int, seed int64) Tour {
+		return StochasticTwoOpt(dist, size, StochasticTwoOptOptions{Iterations: 10000, Temperature: 1, Cooling: .999, Seed: seed})
+	})
+}
+
+func BenchmarkSimulatedAnnealing15(b *testing.B) {
+	annealing15(b, func(dist []float64, size int, seed int64) Tour {
+		return SimulatedAnnealing(dist, size, nil, SAOptions{Iterations: 10000, Temperature: 1, Cooling: .999, Seed: seed, InitMethod: InitRandom})
+	})
+}
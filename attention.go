@@ -0,0 +1,59 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+)
+
+// NeuralAttentionDecode decodes a tour from the embedding trained by Neural
+// like a pointer network. Starting at city 0, the current city attends to the
+// unvisited cities with scaled dot product attention and the city with the
+// largest attention weight is visited next. The embedding of city i is
+// w[i+k*size] for k < scale*size.
+func NeuralAttentionDecode(w []float64, size, scale int) []int {
+	dims := scale * size
+	visited := make([]bool, size)
+	state := 0
+	visited[state] = true
+	route := make([]int, 0, size+1)
+	route = append(route, state)
+	weights := make([]float64, size)
+	for i := 0; i < size-1; i++ {
+		max := math.Inf(-1)
+		for j := 0; j < size; j++ {
+			if visited[j] {
+				continue
+			}
+			dot := 0.0
+			for k := 0; k < dims; k++ {
+				dot += w[state+k*size] * w[j+k*size]
+			}
+			weights[j] = dot / math.Sqrt(float64(dims))
+			max = math.Max(max, weights[j])
+		}
+		sum := 0.0
+		for j := 0; j < size; j++ {
+			if visited[j] {
+				continue
+			}
+			weights[j] = math.Exp(weights[j] - max)
+			sum += weights[j]
+		}
+		best, next := -1.0, 0
+		for j := 0; j < size; j++ {
+			if visited[j] {
+				continue
+			}
+			if weight := weights[j] / sum; weight > best {
+				best, next = weight, j
+			}
+		}
+		state = next
+		visited[state] = true
+		route = append(route, state)
+	}
+	return append(route, route[0])
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNeuralAttentionDecode(t *testing.T) {
+	// city 0 is aligned with city 2, which is aligned with city 1
+	w := make([]float64, 4*2*4)
+	copy(w, []float64{
+		1, 0, 1, 0,
+		0, 1, 1, 0,
+	})
+	route := NeuralAttentionDecode(w, 4, 2)
+	if expected := []int{0, 2, 1, 3, 0}; !equal(route, expected) {
+		t.Errorf("Expected %v, got %v", expected, route)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	rand.Seed(1)
+	attention, euclidean := 0.0, 0.0
+	for i := 0; i < 16; i++ {
+		dist := randomInstance(rng, Size)
+		embedding := neuralEmbedding(dist, 4)
+		route = NeuralAttentionDecode(embedding, Size, 4)
+		if err := ValidateTour(route, Size); err != nil {
+			t.Fatal(err)
+		}
+		attention += TourCost(dist, Size, route)
+		cost, _ := neuralDecode(dist, embedding, 4)
+		euclidean += cost
+	}
+	t.Logf("Attention decoder total cost %f, euclidean decoder total cost %f", attention, euclidean)
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+
+	"github.com/pointlander/gradient/tf64"
+)
+
+// batchNormEpsilon keeps the normalization finite for rows without variance
+const batchNormEpsilon = 1e-5
+
+// batchNorm is a tf64 operation that normalizes each row of a to mean 0 and
+// variance 1 over the batch in the row and then scales the row by gamma and
+// shifts it by beta
+func batchNorm(k tf64.Continuation, v ...*tf64.V) bool {
+	a, gamma, beta := v[0], v[1], v[2]
+	width, rows := a.S[0], a.S[1]
+	n := float64(width)
+	c := tf64.NewV(a.S...)
+	normalized := make([]float64, len(a.X))
+	deviations := make([]float64, rows)
+	for r := 0; r < rows; r++ {
+		row := a.X[r*width : (r+1)*width]
+		mean, variance := 0.0, 0.0
+		for _, x := range row {
+			mean += x
+		}
+		mean /= n
+		for _, x := range row {
+			variance += (x - mean) * (x - mean)
+		}
+		variance /= n
+		deviations[r] = math.Sqrt(variance + batchNormEpsilon)
+		for i, x := range row {
+			normalized[r*width+i] = (x - mean) / deviations[r]
+			c.X = append(c.X, gamma.X[r]*normalized[r*width+i]+beta.X[r])
+		}
+	}
+	if k(&c) {
+		return true
+	}
+	for r := 0; r < rows; r++ {
+		sum, dot := 0.0, 0.0
+		for i := r * width; i < (r+1)*width; i++ {
+			gamma.D[r] += c.D[i] * normalized[i]
+			beta.D[r] += c.D[i]
+			d := c.D[i] * gamma.X[r]
+			sum += d
+			dot += d * normalized[i]
+		}
+		for i := r * width; i < (r+1)*width; i++ {
+			d := c.D[i] * gamma.X[r]
+			a.D[i] += (n*d - sum - normalized[i]*dot) / (n * deviations[r])
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/pointlander/gradient/tf64"
+)
+
+func TestBatchNorm(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	set := tf64.NewSet()
+	set.Add("a", 4, 3)
+	set.Add("gamma", 3)
+	set.Add("beta", 3)
+	set.Add("target", 4, 3)
+	for _, w := range set.Weights {
+		for i := 0; i < cap(w.X); i++ {
+			w.X = append(w.X, rng.NormFloat64())
+		}
+	}
+	normalized := tf64.Op(batchNorm)(set.Get("a"), set.Get("gamma"), set.Get("beta"))
+	cost := tf64.Sum(tf64.Quadratic(normalized, set.Get("target")))
+	set.Zero()
+	tf64.Gradient(cost)
+	// compare the derivatives to finite differences, which accumulate more
+	// derivatives so they are copied first
+	derivatives := make([][]float64, 3)
+	for i, w := range set.Weights[:3] {
+		derivatives[i] = append([]float64{}, w.D...)
+	}
+	for j, w := range set.Weights[:3] {
+		for i := range w.X {
+			x := w.X[i]
+			w.X[i] = x + 1e-6
+			plus := tf64.Gradient(cost).X[0]
+			w.X[i] = x - 1e-6
+			minus := tf64.Gradient(cost).X[0]
+			w.X[i] = x
+			if numeric := (plus - minus) / 2e-6; math.Abs(numeric-derivatives[j][i]) > 1e-4 {
+				t.Errorf("Expected the derivative of %s[%d] to be %f, got %f", w.N, i, numeric, derivatives[j][i])
+			}
+		}
+	}
+}
+
+func TestNeuralBatchNorm(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	iterations := map[bool]int{}
+	for i := 0; i < 8; i++ {
+		dist := randomInstance(rng, Size)
+		for _, batchNorm := range []bool{false, true} {
+			rand.Seed(int64(i))
+			_, _, n := neuralTrain(dist, 4, 1024, NeuralOptions{MaxGradNorm: DefaultMaxGradNorm, BatchNorm: batchNorm})
+			iterations[batchNorm] += n
+		}
+	}
+	if iterations[true] >= iterations[false] {
+		t.Errorf("Expected batch norm to converge in fewer iterations, got %d with and %d without",
+			iterations[true], iterations[false])
+	}
+}
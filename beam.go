@@ -0,0 +1,122 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// MinimumSpanningTree computes the cost of the minimum spanning tree over the
+// given cities using Prim's algorithm, edges are undirected and take the
+// cheaper of the two directions
+func MinimumSpanningTree(dist []float64, size int, cities []int) float64 {
+	total, _ := minimumSpanningTree(dist, size, cities)
+	return total
+}
+
+// minimumSpanningTree computes the minimum spanning tree over the given cities
+// returning its cost and edges
+func minimumSpanningTree(dist []float64, size int, cities []int) (float64, []Edge) {
+	if len(cities) < 2 {
+		return 0, nil
+	}
+	in := make([]bool, len(cities))
+	key, from := make([]float64, len(cities)), make([]int, len(cities))
+	for i := range key {
+		key[i] = math.MaxFloat64
+	}
+	key[0], from[0] = 0, -1
+	total, edges := 0.0, make([]Edge, 0, len(cities)-1)
+	for range cities {
+		min, u := math.MaxFloat64, -1
+		for i, k := range key {
+			if !in[i] && k < min {
+				min, u = k, i
+			}
+		}
+		in[u] = true
+		total += min
+		if from[u] >= 0 {
+			edges = append(edges, Edge{
+				From:   cities[from[u]],
+				To:     cities[u],
+				Weight: min,
+			})
+		}
+		for v := range cities {
+			if in[v] {
+				continue
+			}
+			a, b := cities[u], cities[v]
+			d := math.Min(dist[a*size+b], dist[b*size+a])
+			if d < key[v] {
+				key[v], from[v] = d, u
+			}
+		}
+	}
+	return total, edges
+}
+
+// BeamSearch builds a tour from city 0 keeping the best width partial tours
+// at each step. Partial tours are ranked by their cost plus the minimum spanning
+// tree of the cities left to visit including the last city, so all extensions
+// of the same partial tour share the same bound and a width of 1 is nearest
+// neighbor.
+func BeamSearch(dist []float64, size int, width int) Tour {
+	type Partial struct {
+		Route   []int
+		Visited []bool
+		Cost    float64
+		Score   float64
+	}
+	if width < 1 {
+		width = 1
+	}
+	beam := []Partial{{
+		Route:   []int{0},
+		Visited: make([]bool, size),
+	}}
+	beam[0].Visited[0] = true
+	for step := 1; step < size; step++ {
+		children := make([]Partial, 0, len(beam)*(size-step))
+		for _, parent := range beam {
+			last := parent.Route[len(parent.Route)-1]
+			remaining := []int{last}
+			for j, v := range parent.Visited {
+				if !v {
+					remaining = append(remaining, j)
+				}
+			}
+			bound := MinimumSpanningTree(dist, size, remaining)
+			for _, j := range remaining[1:] {
+				visited := append([]bool{}, parent.Visited...)
+				visited[j] = true
+				cost := parent.Cost + dist[last*size+j]
+				children = append(children, Partial{
+					Route:   append(append(make([]int, 0, size+1), parent.Route...), j),
+					Visited: visited,
+					Cost:    cost,
+					Score:   cost + bound,
+				})
+			}
+		}
+		sort.SliceStable(children, func(i, j int) bool {
+			return children[i].Score < children[j].Score
+		})
+		if len(children) > width {
+			children = children[:width]
+		}
+		beam = children
+	}
+	best := Tour{Cost: math.MaxFloat64}
+	for _, partial := range beam {
+		route := append(partial.Route, partial.Route[0])
+		if cost := TourCost(dist, size, route); cost < best.Cost {
+			best.Cost, best.Route = cost, route
+		}
+	}
+	return best
+}
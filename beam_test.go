@@ -0,0 +1,39 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBeamSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	narrow, wide := 0.0, 0.0
+	for i := 0; i < 20; i++ {
+		dist := randomInstance(rng, 8)
+		nn := nearestNeighbor(dist, 8, 0)
+		tour := BeamSearch(dist, 8, 1)
+		if !equal(tour.Route, nn.Route) {
+			t.Errorf("Expected width 1 to be nearest neighbor %v, got %v", nn.Route, tour.Route)
+		}
+		narrow += tour.Cost
+		tour = BeamSearch(dist, 8, 16)
+		if err := ValidateTour(tour.Route, 8); err != nil {
+			t.Errorf("Invalid tour: %v", err)
+		}
+		wide += tour.Cost
+	}
+	if wide >= narrow {
+		t.Errorf("Expected a wide beam to beat %f, got %f", narrow, wide)
+	}
+}
+
+func BenchmarkBeamSearch(b *testing.B) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 15)
+	for i := 0; i < b.N; i++ {
+		BeamSearch(dist, 15, 8)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+const (
+	// AlgorithmPageRank is the index of PageRank in the results
+	AlgorithmPageRank = iota
+	// AlgorithmEigen is the index of Eigen in the results
+	AlgorithmEigen
+	// AlgorithmEigen2 is the index of Eigen2 in the results
+	AlgorithmEigen2
+	// AlgorithmNearestNeighbor is the index of NearestNeighbor in the results
+	AlgorithmNearestNeighbor
+	// AlgorithmNeural2 is the index of Neural2 in the results
+	AlgorithmNeural2
+	// AlgorithmNearestNeighborPCA is the index of NearestNeighborPCA in the results
+	AlgorithmNearestNeighborPCA
+	// AlgorithmHITS is the index of HITS in the results
+	AlgorithmHITS
+	// AlgorithmLaplacian is the index of LaplacianEigen in the results
+	AlgorithmLaplacian
+	// AlgorithmPageRankWeightedNN is the index of PageRankWeightedNN in the results
+	AlgorithmPageRankWeightedNN
+	// AlgorithmHybridEigenNN is the index of HybridEigenNN in the results
+	AlgorithmHybridEigenNN
+)
+
+// Algorithms are the names of the algorithms in the results
+var Algorithms = []string{"PageRank", "Eigen", "Eigen2", "NearestNeighbor", "Neural2", "NearestNeighborPCA", "HITS", "Laplacian", "PageRankWeightedNN", "HybridEigenNN"}
+
+// TestResult is the result of one trial of the benchmark
+type TestResult struct {
+	// Optimal is the cost found by Search
+	Optimal float64 `json:"optimal"`
+	// Costs are the costs found by each algorithm
+	Costs []float64 `json:"costs"`
+	// Elapsed are the wall clock times of each algorithm
+	Elapsed []time.Duration `json:"elapsed"`
+}
+
+// Trials are the results of running the benchmark
+type Trials struct {
+	Results []TestResult
+	// EigenCount is the number of trials where Neural2 was optimal
+	EigenCount int64
+	// NNCount is the number of trials where NearestNeighbor was optimal
+	NNCount int64
+}
+
+// RunTrials runs the benchmark trials split across parallel goroutines. Trial
+// i uses its own random number generator seeded with seed+i, so the results
+// don't depend on the number of goroutines.
+func RunTrials(trials, parallel int, seed int64) Trials {
+	if parallel < 1 {
+		parallel = 1
+	}
+	result := Trials{
+		Results: make([]TestResult, trials),
+	}
+	var wait sync.WaitGroup
+	for g := 0; g < parallel; g++ {
+		wait.Add(1)
+		go func(g int) {
+			defer wait.Done()
+			for i := g; i < trials; i += parallel {
+				r := test(rand.New(rand.NewSource(seed + int64(i))))
+				if r.Optimal == r.Costs[AlgorithmNeural2] {
+					atomic.AddInt64(&result.EigenCount, 1)
+				}
+				if r.Optimal == r.Costs[AlgorithmNearestNeighbor] {
+					atomic.AddInt64(&result.NNCount, 1)
+				}
+				result.Results[i] = r
+			}
+		}(g)
+	}
+	wait.Wait()
+	return result
+}
+
+// Statistics are the benchmark statistics of an algorithm
+type Statistics struct {
+	Algorithm string `json:"algorithm"`
+	// Win is the fraction of trials where the algorithm was optimal
+	Win float64 `json:"win"`
+	// Tie is the fraction of trials where the algorithm wasn't optimal but
+	// matched another algorithm
+	Tie float64 `json:"tie"`
+	// MeanGap is the mean percentage above optimal
+	MeanGap float64 `json:"mean_gap"`
+	// MeanElapsed is the mean wall clock time
+	MeanElapsed time.Duration `json:"mean_elapsed"`
+	// P95Elapsed is the 95th percentile of the wall clock time
+	P95Elapsed time.Duration `json:"p95_elapsed"`
+}
+
+// Summarize computes the statistics of each algorithm over the trials
+func Summarize(results []TestResult) []Statistics {
+	stats := make([]Statistics, len(Algorithms))
+	for i := range stats {
+		stats[i].Algorithm = Algorithms[i]
+	}
+	if len(results) == 0 {
+		return stats
+	}
+	for _, result := range results {
+		for i, cost := range result.Costs {
+			if cost == result.Optimal {
+				stats[i].Win++
+			} else {
+				for j, other := range result.Costs {
+					if j != i && other == cost {
+						stats[i].Tie++
+						break
+					}
+				}
+			}
+			if result.Optimal != 0 {
+				stats[i].MeanGap += 100 * (cost - result.Optimal) / result.Optimal
+			}
+		}
+	}
+	n := float64(len(results))
+	for i := range stats {
+		stats[i].Win /= n
+		stats[i].Tie /= n
+		stats[i].MeanGap /= n
+		elapsed := make([]float64, 0, len(results))
+		for _, result := range results {
+			if i < len(result.Elapsed) {
+				elapsed = append(elapsed, float64(result.Elapsed[i]))
+			}
+		}
+		if len(elapsed) == 0 {
+			continue
+		}
+		sort.Float64s(elapsed)
+		stats[i].MeanElapsed = time.Duration(stat.Mean(elapsed, nil))
+		stats[i].P95Elapsed = time.Duration(stat.Quantile(.95, stat.Empirical, elapsed, nil))
+	}
+	return stats
+}
+
+// PrintStatistics prints the statistics as a table
+func PrintStatistics(w io.Writer, stats []Statistics) {
+	fmt.Fprintf(w, "%-18s | %7s | %7s | %8s | %12s | %12s\n", "Algorithm", "Win%", "Tie%", "MeanGap%", "MeanElapsed", "P95Elapsed")
+	for _, s := range stats {
+		fmt.Fprintf(w, "%-18s | %7.2f | %7.2f | %8.2f | %12s | %12s\n", s.Algorithm, 100*s.Win, 100*s.Tie, s.MeanGap,
+			s.MeanElapsed.Round(time.Microsecond), s.P95Elapsed.Round(time.Microsecond))
+	}
+}
+
+// Correlations computes the Pearson correlation between the costs of each pair
+// of algorithms over the trials. A high correlation means the algorithms fail
+// on the same instances, a low correlation means they are complementary.
+func Correlations(results []TestResult) [][]float64 {
+	costs := make([][]float64, len(Algorithms))
+	for i := range costs {
+		costs[i] = make([]float64, len(results))
+		for j, result := range results {
+			costs[i][j] = result.Costs[i]
+		}
+	}
+	correlations := make([][]float64, len(Algorithms))
+	for i := range correlations {
+		correlations[i] = make([]float64, len(Algorithms))
+		for j := range correlations[i] {
+			correlations[i][j] = stat.Correlation(costs[i], costs[j], nil)
+		}
+	}
+	return correlations
+}
+
+// PrintCorrelations prints the correlation matrix as a table
+func PrintCorrelations(w io.Writer, correlations [][]float64) {
+	fmt.Fprintf(w, "%-18s", "")
+	for _, algorithm := range Algorithms {
+		fmt.Fprintf(w, " | %18s", algorithm)
+	}
+	fmt.Fprintf(w, "\n")
+	for i, row := range correlations {
+		fmt.Fprintf(w, "%-18s", Algorithms[i])
+		for _, correlation := range row {
+			fmt.Fprintf(w, " | %18.2f", correlation)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+// TimeToOptimal measures how long it takes to find a tour costing at most
+// target times the cost of the optimal tour from Search, by solving the
+// instance until the solver finds one. A deterministic solver should vary its
+// seed between solves. It returns maxTime if no solve finds such a tour
+// within maxTime.
+func TimeToOptimal(dist []float64, size int, solver Solver, target float64, maxTime time.Duration) time.Duration {
+	optimal, _ := Search(dist)
+	start := time.Now()
+	for {
+		tour := solver.Solve(dist, size)
+		elapsed := time.Since(start)
+		if tour.Cost <= target*optimal {
+			return elapsed
+		}
+		if elapsed >= maxTime {
+			return maxTime
+		}
+	}
+}
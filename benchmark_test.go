@@ -0,0 +1,141 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	results := []TestResult{
+		{Optimal: 10, Costs: []float64{10, 12, 12, 10, 15}},
+		{Optimal: 10, Costs: []float64{11, 10, 13, 10, 14}},
+	}
+	stats := Summarize(results)
+	expected := []Statistics{
+		{Algorithm: "PageRank", Win: .5, Tie: 0, MeanGap: 5},
+		{Algorithm: "Eigen", Win: .5, Tie: .5, MeanGap: 10},
+		{Algorithm: "Eigen2", Win: 0, Tie: .5, MeanGap: 25},
+		{Algorithm: "NearestNeighbor", Win: 1, Tie: 0, MeanGap: 0},
+		{Algorithm: "Neural2", Win: 0, Tie: 0, MeanGap: 45},
+	}
+	for i, s := range expected {
+		if s != stats[i] {
+			t.Errorf("Expected %+v, got %+v", s, stats[i])
+		}
+	}
+}
+
+func TestSummarizeElapsed(t *testing.T) {
+	results := make([]TestResult, 20)
+	for i := range results {
+		results[i] = TestResult{
+			Optimal: 10,
+			Costs:   []float64{10},
+			Elapsed: []time.Duration{time.Duration(i+1) * time.Millisecond},
+		}
+	}
+	stats := Summarize(results)
+	if stats[0].MeanElapsed != 10500*time.Microsecond {
+		t.Errorf("Expected a mean of 10.5ms, got %v", stats[0].MeanElapsed)
+	}
+	if stats[0].P95Elapsed != 19*time.Millisecond {
+		t.Errorf("Expected a 95th percentile of 19ms, got %v", stats[0].P95Elapsed)
+	}
+	if stats[1].MeanElapsed != 0 {
+		t.Errorf("Expected no elapsed time for an algorithm without times, got %v", stats[1].MeanElapsed)
+	}
+}
+
+func TestCorrelations(t *testing.T) {
+	results := []TestResult{
+		{Optimal: 10, Costs: []float64{10, 12, 14, 16, 10, 10, 10, 10, 10, 10}},
+		{Optimal: 10, Costs: []float64{11, 11, 13, 17, 11, 12, 10, 10, 10, 10}},
+		{Optimal: 10, Costs: []float64{12, 10, 12, 18, 12, 11, 10, 10, 10, 10}},
+	}
+	correlations := Correlations(results)
+	expected := [][2]int{
+		{AlgorithmPageRank, AlgorithmPageRank},
+		{AlgorithmPageRank, AlgorithmNearestNeighbor},
+		{AlgorithmPageRank, AlgorithmNeural2},
+	}
+	for _, pair := range expected {
+		if c := correlations[pair[0]][pair[1]]; math.Abs(c-1) > 1e-9 {
+			t.Errorf("Expected %s and %s to be correlated, got %f", Algorithms[pair[0]], Algorithms[pair[1]], c)
+		}
+	}
+	if c := correlations[AlgorithmPageRank][AlgorithmEigen]; math.Abs(c+1) > 1e-9 {
+		t.Errorf("Expected PageRank and Eigen to be anticorrelated, got %f", c)
+	}
+	if c, d := correlations[AlgorithmEigen][AlgorithmNearestNeighborPCA], correlations[AlgorithmNearestNeighborPCA][AlgorithmEigen]; c != d {
+		t.Errorf("Expected a symmetric matrix, got %f and %f", c, d)
+	}
+}
+
+func TestRunTrials(t *testing.T) {
+	serial, parallel := RunTrials(8, 1, 1), RunTrials(8, 4, 1)
+	if serial.EigenCount != parallel.EigenCount || serial.NNCount != parallel.NNCount {
+		t.Errorf("Expected counts %d %d, got %d %d",
+			serial.EigenCount, serial.NNCount, parallel.EigenCount, parallel.NNCount)
+	}
+	for i, result := range serial.Results {
+		if result.Optimal != parallel.Results[i].Optimal {
+			t.Errorf("Expected optimal %f for trial %d, got %f", result.Optimal, i, parallel.Results[i].Optimal)
+		}
+		for j, cost := range result.Costs {
+			if cost != parallel.Results[i].Costs[j] {
+				t.Errorf("Expected %s cost %f for trial %d, got %f", Algorithms[j], cost, i, parallel.Results[i].Costs[j])
+			}
+		}
+	}
+}
+
+func BenchmarkRunTrialsSerial(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		RunTrials(16, 1, 1)
+	}
+}
+
+func BenchmarkRunTrialsParallel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		RunTrials(16, runtime.NumCPU(), 1)
+	}
+}
+
+// multiStartSA solves with simulated annealing from a new seed each solve
+func multiStartSA(iterations int) Solver {
+	seed := int64(0)
+	return SolverFunc(func(dist []float64, size int) Tour {
+		seed++
+		return SimulatedAnnealing(dist, size, nil, SAOptions{Iterations: iterations, Temperature: 1, Cooling: .99, Seed: seed, InitMethod: InitRandom})
+	})
+}
+
+func TestTimeToOptimal(t *testing.T) {
+	maxTime := 10 * time.Second
+	if elapsed := TimeToOptimal(canonical, Size, multiStartSA(10), 1, maxTime); elapsed >= maxTime {
+		t.Errorf("Expected multi-start SA to find the optimal tour before %v", maxTime)
+	}
+	never := SolverFunc(func(dist []float64, size int) Tour {
+		return Tour{Cost: math.Inf(1)}
+	})
+	if elapsed := TimeToOptimal(canonical, Size, never, 1, time.Millisecond); elapsed != time.Millisecond {
+		t.Errorf("Expected %v when no tour is found, got %v", time.Millisecond, elapsed)
+	}
+}
+
+func BenchmarkTimeToOptimal(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 10)
+	var elapsed time.Duration
+	for n := 0; n < b.N; n++ {
+		elapsed += TimeToOptimal(dist, 10, multiStartSA(1000), 1.05, time.Minute)
+	}
+	b.ReportMetric(float64(elapsed.Microseconds())/float64(b.N), "us/optimal")
+}
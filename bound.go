@@ -0,0 +1,31 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/pointlander/salesman/internal/hungarian"
+)
+
+// AssignmentLowerBound computes a lower bound on the tour cost by giving every
+// city exactly one outgoing and one incoming edge at minimum cost. The bound
+// allows subtours so it is tighter than the minimum spanning tree for
+// asymmetric instances but not always for symmetric ones.
+func AssignmentLowerBound(dist []float64, size int) float64 {
+	if size < 2 {
+		return 0
+	}
+	// a city can't be assigned to itself
+	forbidden := 1.0
+	for _, d := range dist {
+		forbidden += d
+	}
+	cost := make([]float64, size*size)
+	copy(cost, dist)
+	for i := 0; i < size; i++ {
+		cost[i*size+i] = forbidden
+	}
+	_, total := hungarian.Hungarian(cost, size)
+	return total
+}
@@ -0,0 +1,29 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAssignmentLowerBound(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 256; i++ {
+		dist := make([]float64, Size*Size)
+		for j := range dist {
+			if j%(Size+1) != 0 {
+				dist[j] = float64(rng.Intn(8) + 1)
+			}
+		}
+		optimal, _ := Search(dist)
+		if bound := AssignmentLowerBound(dist, Size); bound > optimal {
+			t.Errorf("Expected bound %f to be at most the optimal cost %f for %v", bound, optimal, dist)
+		}
+	}
+	if bound := AssignmentLowerBound(canonical, Size); bound != 64 {
+		t.Errorf("Expected bound of 64, got %f", bound)
+	}
+}
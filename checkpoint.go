@@ -0,0 +1,101 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+)
+
+// Checkpoint is the state of a simulated annealing run, resuming from it
+// continues the run exactly where it stopped
+type Checkpoint struct {
+	// Options are the options of the run
+	Options SAOptions `json:"options"`
+	// Iterations is the number of iterations run so far
+	Iterations int `json:"iterations"`
+	// Best is the best tour found so far
+	Best tourJSON `json:"best"`
+	// Current is the tour the search is at
+	Current tourJSON `json:"current"`
+	// Temperature is the current temperature
+	Temperature float64 `json:"temperature"`
+	// Draws is the number of values drawn from the random number generator
+	Draws uint64 `json:"draws"`
+}
+
+// countingSource is a random number source that counts the values drawn, so
+// the state of the source can be restored by drawing the same number of
+// values from a new source with the same seed
+type countingSource struct {
+	source rand.Source
+	draws  uint64
+}
+
+// newCountingSource returns a source seeded with seed that has already drawn
+// draws values
+func newCountingSource(seed int64, draws uint64) *countingSource {
+	c := &countingSource{source: rand.NewSource(seed)}
+	for c.draws < draws {
+		c.Int63()
+	}
+	return c
+}
+
+// Int63 draws a value from the source
+func (c *countingSource) Int63() int64 {
+	c.draws++
+	return c.source.Int63()
+}
+
+// Seed seeds the source and resets the count
+func (c *countingSource) Seed(seed int64) {
+	c.draws = 0
+	c.source.Seed(seed)
+}
+
+// WriteCheckpoint writes the checkpoint to the file as json, the file is
+// replaced atomically so an interrupted write leaves the previous checkpoint
+func WriteCheckpoint(path string, c Checkpoint) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	temp := path + ".tmp"
+	err = os.WriteFile(temp, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
+
+// ReadCheckpoint reads a checkpoint written by WriteCheckpoint
+func ReadCheckpoint(path string) (Checkpoint, error) {
+	var c Checkpoint
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return c, fmt.Errorf("invalid checkpoint %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// ResumeSimulatedAnnealing continues the simulated annealing run of the
+// checkpoint on the instance, the result is the same as the result of the
+// uninterrupted run
+func ResumeSimulatedAnnealing(dist []float64, size int, c Checkpoint) (Tour, error) {
+	if err := ValidateTour(c.Current.Route, size); err != nil {
+		return Tour{}, fmt.Errorf("checkpoint doesn't match the instance: %w", err)
+	}
+	if err := ValidateTour(c.Best.Route, size); err != nil {
+		return Tour{}, fmt.Errorf("checkpoint doesn't match the instance: %w", err)
+	}
+	return anneal(dist, size, c, newCountingSource(c.Options.Seed, c.Draws), hooks{}), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpoint(t *testing.T) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 12)
+	opts := SAOptions{Iterations: 2000, Temperature: 20, Cooling: .999, Seed: 1, InitMethod: InitRandom}
+	uninterrupted := SimulatedAnnealing(dist, 12, nil, opts)
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	interrupted := opts
+	interrupted.Iterations = 1000
+	interrupted.CheckpointInterval, interrupted.CheckpointPath = 300, path
+	SimulatedAnnealing(dist, 12, nil, interrupted)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("Expected the checkpoint to be json, got %s", data)
+	}
+	checkpoint, err := ReadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.Iterations != 900 {
+		t.Errorf("Expected the last checkpoint at 900 iterations, got %d", checkpoint.Iterations)
+	}
+
+	checkpoint.Options.Iterations = opts.Iterations
+	resumed, err := ResumeSimulatedAnnealing(dist, 12, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.Cost != uninterrupted.Cost || !equal(resumed.Route, uninterrupted.Route) ||
+		resumed.Iterations != uninterrupted.Iterations {
+		t.Errorf("Expected the resumed run to end with %f %v after %d iterations, got %f %v after %d",
+			uninterrupted.Cost, uninterrupted.Route, uninterrupted.Iterations, resumed.Cost, resumed.Route, resumed.Iterations)
+	}
+	if _, err := ResumeSimulatedAnnealing(canonical, Size, checkpoint); err == nil {
+		t.Error("Expected an error for a checkpoint of a different instance")
+	}
+}
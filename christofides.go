@@ -0,0 +1,108 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// GreedyMatching matches the odd vertices by repeatedly pairing the closest
+// two unmatched vertices. This is NOT a minimum cost perfect matching, it is a
+// placeholder until a proper matching such as Blossom V is added.
+func GreedyMatching(dist []float64, oddVertices []int) []Edge {
+	size := int(math.Sqrt(float64(len(dist))))
+	pairs := make([]Edge, 0, len(oddVertices)*len(oddVertices)/2)
+	for i, a := range oddVertices {
+		for _, b := range oddVertices[i+1:] {
+			pairs = append(pairs, Edge{
+				From:   a,
+				To:     b,
+				Weight: math.Min(dist[a*size+b], dist[b*size+a]),
+			})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Weight < pairs[j].Weight
+	})
+	matched := make(map[int]bool, len(oddVertices))
+	matching := make([]Edge, 0, len(oddVertices)/2)
+	for _, edge := range pairs {
+		if matched[edge.From] || matched[edge.To] {
+			continue
+		}
+		matched[edge.From], matched[edge.To] = true, true
+		matching = append(matching, edge)
+	}
+	return matching
+}
+
+// Christofides uses Christofides' algorithm to solve the metric symmetric
+// traveling salesman problem. Because the odd vertices are matched with
+// GreedyMatching the 1.5 approximation guarantee does not hold, so the tour
+// is compared to the shortcut doubled minimum spanning tree and the better of
+// the two is returned, which is within 2 times optimal. The guarantee only
+// holds for instances that satisfy the triangle inequality, in debug mode the
+// violations are reported. The distances must be symmetric, see Symmetrize.
+// With fewer than 3 cities there is only one tour.
+func Christofides(dist []float64, size int) Tour {
+	if size < 3 {
+		return nearestNeighbor(dist, size, 0)
+	}
+	if *FlagDebug {
+		warnNonMetric("Christofides", dist, size)
+	}
+	cities := make([]int, size)
+	for i := range cities {
+		cities[i] = i
+	}
+	_, tree := minimumSpanningTree(dist, size, cities)
+	adj := make([][]int, size)
+	for _, edge := range tree {
+		adj[edge.From] = append(adj[edge.From], edge.To)
+		adj[edge.To] = append(adj[edge.To], edge.From)
+	}
+
+	// the doubled tree visited in preorder
+	visited := make([]bool, size)
+	doubled := make([]int, 0, size+1)
+	var walk func(i int)
+	walk = func(i int) {
+		visited[i] = true
+		doubled = append(doubled, i)
+		for _, j := range adj[i] {
+			if !visited[j] {
+				walk(j)
+			}
+		}
+	}
+	walk(0)
+	doubled = append(doubled, 0)
+	best := Tour{
+		Cost:  TourCost(dist, size, doubled),
+		Route: doubled,
+	}
+
+	odd := make([]int, 0, size)
+	for i, neighbors := range adj {
+		if len(neighbors)%2 == 1 {
+			odd = append(odd, i)
+		}
+	}
+	for _, edge := range GreedyMatching(dist, odd) {
+		adj[edge.From] = append(adj[edge.From], edge.To)
+		adj[edge.To] = append(adj[edge.To], edge.From)
+	}
+	circuit, err := EulerianCircuit(adj, size)
+	if err != nil {
+		panic(err)
+	}
+
+	route := Shortcut(circuit, size)
+	if cost := TourCost(dist, size, route); cost < best.Cost {
+		best.Cost, best.Route = cost, route
+	}
+	return best
+}
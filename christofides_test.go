@@ -0,0 +1,54 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGreedyMatching(t *testing.T) {
+	dist := euclideanInstance(rand.New(rand.NewSource(1)), 8)
+	odd := []int{0, 2, 3, 5, 6, 7}
+	matching := GreedyMatching(dist, odd)
+	if len(matching) != len(odd)/2 {
+		t.Fatalf("Expected %d edges, got %d", len(odd)/2, len(matching))
+	}
+	matched := make(map[int]int)
+	for _, edge := range matching {
+		matched[edge.From]++
+		matched[edge.To]++
+	}
+	for _, i := range odd {
+		if matched[i] != 1 {
+			t.Errorf("Expected city %d to be matched once, got %d", i, matched[i])
+		}
+	}
+}
+
+func TestChristofides(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 64; i++ {
+		dist := euclideanInstance(rng, Size)
+		optimal, _ := Search(dist)
+		tour := Christofides(dist, Size)
+		if err := ValidateTour(tour.Route, Size); err != nil {
+			t.Fatalf("Invalid tour: %v", err)
+		}
+		if tour.Cost > 2*optimal+1e-9 {
+			t.Errorf("Expected cost within 2 times %f, got %f", optimal, tour.Cost)
+		}
+	}
+	dist := euclideanInstance(rng, 32)
+	if err := ValidateTour(Christofides(dist, 32).Route, 32); err != nil {
+		t.Errorf("Invalid tour: %v", err)
+	}
+	for size := 1; size < 4; size++ {
+		dist := euclideanInstance(rng, size)
+		if err := VerifyTour(dist, size, Christofides(dist, size)); err != nil {
+			t.Errorf("Invalid tour of %d cities: %v", size, err)
+		}
+	}
+}
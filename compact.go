@@ -0,0 +1,56 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// CompactTour stores the route of a tour as the first city followed by the
+// differences between consecutive cities, which takes a quarter of the memory
+// of a route on 64 bit platforms. It works for up to 32767 cities.
+type CompactTour struct {
+	cost      float64
+	firstCity uint16
+	// deltas are route[i+1] - route[i], the return to the first city is
+	// left out
+	deltas []int16
+}
+
+// Compact compresses the tour, it panics if a city doesn't fit in an int16.
+// Only the cost and the route are kept.
+func Compact(t Tour) CompactTour {
+	for _, city := range t.Route {
+		if city < 0 || city > math.MaxInt16 {
+			panic(fmt.Sprintf("city %d doesn't fit in a compact tour", city))
+		}
+	}
+	ct := CompactTour{cost: t.Cost}
+	if len(t.Route) == 0 {
+		return ct
+	}
+	ct.firstCity = uint16(t.Route[0])
+	ct.deltas = make([]int16, len(t.Route)-2)
+	for i := range ct.deltas {
+		ct.deltas[i] = int16(t.Route[i+1] - t.Route[i])
+	}
+	return ct
+}
+
+// Expand decompresses the tour
+func (ct CompactTour) Expand() Tour {
+	t := Tour{Cost: ct.cost}
+	if ct.deltas == nil {
+		return t
+	}
+	t.Route = make([]int, len(ct.deltas)+2)
+	t.Route[0] = int(ct.firstCity)
+	for i, delta := range ct.deltas {
+		t.Route[i+1] = t.Route[i] + int(delta)
+	}
+	t.Route[len(t.Route)-1] = t.Route[0]
+	return t
+}
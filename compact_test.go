@@ -0,0 +1,72 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+func TestCompactTour(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range []int{1, 2, 4, 100, 32767} {
+		route := append(rng.Perm(size), 0)
+		route[size] = route[0]
+		tour := Tour{Cost: rng.Float64(), Route: route}
+		expanded := Compact(tour).Expand()
+		if expanded.Cost != tour.Cost || !equal(expanded.Route, tour.Route) {
+			t.Errorf("Expected the tour of %d cities to expand to itself", size)
+		}
+	}
+	if expanded := Compact(Tour{Cost: 1}).Expand(); expanded.Cost != 1 || expanded.Route != nil {
+		t.Errorf("Expected an empty route to expand to nil, got %v", expanded.Route)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a city that doesn't fit in an int16")
+		}
+	}()
+	Compact(Tour{Route: []int{0, 40000, 0}})
+}
+
+// tourMemory reports the heap memory per tour of keeping a million 16 city
+// tours with keep
+func tourMemory(b *testing.B, keep func(tours int) func(i int, t Tour)) {
+	const tours = 1000000
+	route := append(rand.New(rand.NewSource(1)).Perm(16), 0)
+	route[16] = route[0]
+	for n := 0; n < b.N; n++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		store := keep(tours)
+		for i := 0; i < tours; i++ {
+			store(i, Tour{Cost: float64(i), Route: append([]int{}, route...)})
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/tours, "bytes/tour")
+		runtime.KeepAlive(store)
+	}
+}
+
+func BenchmarkTourMemory(b *testing.B) {
+	tourMemory(b, func(tours int) func(i int, t Tour) {
+		kept := make([]Tour, tours)
+		return func(i int, t Tour) {
+			kept[i] = t
+		}
+	})
+}
+
+func BenchmarkCompactTourMemory(b *testing.B) {
+	tourMemory(b, func(tours int) func(i int, t Tour) {
+		kept := make([]CompactTour, tours)
+		return func(i int, t Tour) {
+			kept[i] = Compact(t)
+		}
+	})
+}
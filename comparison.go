@@ -0,0 +1,100 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// SolverComparison are the tours found by each solver for each instance
+type SolverComparison struct {
+	// Solvers are the names of the solvers in order
+	Solvers []string
+	// Instances are the instances in order
+	Instances []*Instance
+	// Results are the tours by instance and then by solver, the tour of a
+	// solver that doesn't work with the size of the instance has no route
+	Results [][]Tour
+}
+
+// RunComparison solves each instance with each solver, the solvers are in
+// order of name
+func RunComparison(solvers map[string]Solver, instances []*Instance) *SolverComparison {
+	sc := &SolverComparison{
+		Instances: instances,
+		Results:   make([][]Tour, len(instances)),
+	}
+	for name := range solvers {
+		sc.Solvers = append(sc.Solvers, name)
+	}
+	sort.Strings(sc.Solvers)
+	for i, instance := range instances {
+		sized := SizedSolvers(solvers, instance.Size)
+		sc.Results[i] = make([]Tour, len(sc.Solvers))
+		for j, name := range sc.Solvers {
+			if solver, ok := sized[name]; ok {
+				sc.Results[i][j] = solver.Solve(instance.Dist, instance.Size)
+			}
+		}
+	}
+	return sc
+}
+
+// PrintTable prints a markdown table with a row for each instance and a
+// column for each solver. Each cell is the cost of the tour and its gap to the
+// best tour of the instance, or - if the solver doesn't work with the size of
+// the instance.
+func (sc *SolverComparison) PrintTable(w io.Writer) {
+	rows := make([][]string, 0, len(sc.Instances)+1)
+	rows = append(rows, append([]string{"Instance"}, sc.Solvers...))
+	for i, instance := range sc.Instances {
+		best := math.MaxFloat64
+		for _, tour := range sc.Results[i] {
+			if tour.Route != nil && tour.Cost < best {
+				best = tour.Cost
+			}
+		}
+		row := []string{instance.Name}
+		for _, tour := range sc.Results[i] {
+			if tour.Route == nil {
+				row = append(row, "-")
+				continue
+			}
+			gap := 0.0
+			if best > 0 {
+				gap = 100 * (tour.Cost - best) / best
+			}
+			row = append(row, fmt.Sprintf("%.2f (+%.2f%%)", tour.Cost, gap))
+		}
+		rows = append(rows, row)
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for j, cell := range row {
+			if n := len([]rune(cell)); n > widths[j] {
+				widths[j] = n
+			}
+		}
+	}
+	line := func(row []string) {
+		for j, cell := range row {
+			fmt.Fprintf(w, "| %-*s ", widths[j], cell)
+		}
+		fmt.Fprintln(w, "|")
+	}
+	line(rows[0])
+	separator := make([]string, len(widths))
+	for j, width := range widths {
+		separator[j] = strings.Repeat("-", width)
+	}
+	line(separator)
+	for _, row := range rows[1:] {
+		line(row)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunComparison(t *testing.T) {
+	instances, err := LoadTestInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compared := []*Instance{&instances[0], &instances[1], &instances[2]}
+	solvers := DefaultSolvers()
+	solvers = map[string]Solver{
+		"nearestneighbor": solvers["nearestneighbor"],
+		"beam":            solvers["beam"],
+		"ils":             solvers["ils"],
+	}
+	sc := RunComparison(solvers, compared)
+	if strings.Join(sc.Solvers, ",") != "beam,ils,nearestneighbor" {
+		t.Errorf("Expected the solvers in order of name, got %v", sc.Solvers)
+	}
+	for i, instance := range compared {
+		for j, name := range sc.Solvers {
+			tour := sc.Results[i][j]
+			if name == "nearestneighbor" && instance.Size != Size {
+				if tour.Route != nil {
+					t.Errorf("Expected no %s tour for %s", name, instance.Name)
+				}
+				continue
+			}
+			if err := VerifyTour(instance.Dist, instance.Size, tour); err != nil {
+				t.Errorf("Invalid %s tour for %s: %v", name, instance.Name, err)
+			}
+		}
+	}
+
+	var table bytes.Buffer
+	sc.PrintTable(&table)
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != len(compared)+2 {
+		t.Fatalf("Expected %d lines, got\n%s", len(compared)+2, table.String())
+	}
+	for _, name := range sc.Solvers {
+		if !strings.Contains(lines[0], name) {
+			t.Errorf("Expected the header to contain %s, got %q", name, lines[0])
+		}
+	}
+	for i, instance := range compared {
+		if !strings.HasPrefix(lines[i+2], "| "+instance.Name+" ") {
+			t.Errorf("Expected a row for %s, got %q", instance.Name, lines[i+2])
+		}
+		if !strings.Contains(lines[i+2], "(+0.00%)") {
+			t.Errorf("Expected a best tour for %s, got %q", instance.Name, lines[i+2])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// PlotParallelCoordinates plots the cost of each algorithm on its own vertical
+// axis with a polyline per trial. Trials where every algorithm is optimal are
+// green and trials where they disagree are red.
+func PlotParallelCoordinates(results []TestResult, path string) error {
+	p := plot.New()
+
+	p.Title.Text = "cost per algorithm"
+	p.X.Label.Text = "algorithm"
+	p.Y.Label.Text = "cost"
+
+	ticks := make([]plot.Tick, len(Algorithms))
+	for i, algorithm := range Algorithms {
+		ticks[i] = plot.Tick{Value: float64(i), Label: algorithm}
+	}
+	p.X.Tick.Marker = plot.ConstantTicks(ticks)
+	p.X.Min, p.X.Max = -.5, float64(len(Algorithms))-.5
+
+	grid := plotter.NewGrid()
+	grid.Horizontal.Color = nil
+	p.Add(grid)
+
+	green := color.RGBA{G: 160, A: 255}
+	red := color.RGBA{R: 200, A: 255}
+	var disagree []*plotter.Line
+	for _, result := range results {
+		points := make(plotter.XYs, len(result.Costs))
+		optimal := true
+		for i, cost := range result.Costs {
+			points[i] = plotter.XY{X: float64(i), Y: cost}
+			if cost != result.Optimal {
+				optimal = false
+			}
+		}
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return err
+		}
+		line.LineStyle.Width = vg.Points(.5)
+		if optimal {
+			line.LineStyle.Color = green
+			p.Add(line)
+			continue
+		}
+		line.LineStyle.Color = red
+		disagree = append(disagree, line)
+	}
+	for _, line := range disagree {
+		p.Add(line)
+	}
+
+	return p.Save(16*vg.Inch, 8*vg.Inch, path)
+}
@@ -0,0 +1,25 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlotParallelCoordinates(t *testing.T) {
+	results := []TestResult{
+		{Optimal: 10, Costs: []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10}},
+		{Optimal: 10, Costs: []float64{11, 10, 13, 10, 14, 12, 10, 10, 10, 10}},
+	}
+	path := filepath.Join(t.TempDir(), "parallel.png")
+	if err := PlotParallelCoordinates(results, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error(err)
+	}
+}
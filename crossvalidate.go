@@ -0,0 +1,75 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CVResult is the cross validation result of a solver configuration
+type CVResult struct {
+	// Config is the json configuration of the solver, see NewSolverFromJSON
+	Config string
+	// MeanCost is the mean tour cost over all of the instances
+	MeanCost float64
+	// FoldCosts are the mean tour costs over the instances of each fold
+	FoldCosts []float64
+	// Selected is the number of folds the configuration was selected for,
+	// because it had the lowest mean cost on the other folds
+	Selected int
+}
+
+// CrossValidate tunes the options of a solver by solving the instances with
+// each solver configuration of the grid, see NewSolverFromJSON. Instance i
+// belongs to fold i%k, and the configuration with the lowest mean cost on the
+// other folds is selected for each fold. The results are sorted by mean cost.
+func CrossValidate(instances []*Instance, paramGrid []string, k int) ([]CVResult, error) {
+	if k < 2 || k > len(instances) {
+		return nil, fmt.Errorf("expected between 2 and %d folds, got %d", len(instances), k)
+	}
+	results := make([]CVResult, len(paramGrid))
+	for i, config := range paramGrid {
+		solver, err := NewSolverFromJSON(config)
+		if err != nil {
+			return nil, err
+		}
+		results[i].Config = config
+		results[i].FoldCosts = make([]float64, k)
+		counts := make([]int, k)
+		for j, instance := range instances {
+			cost := solver.Solve(instance.Dist, instance.Size).Cost
+			results[i].MeanCost += cost
+			results[i].FoldCosts[j%k] += cost
+			counts[j%k]++
+		}
+		results[i].MeanCost /= float64(len(instances))
+		for fold := range counts {
+			results[i].FoldCosts[fold] /= float64(counts[fold])
+		}
+	}
+	for fold := 0; fold < k; fold++ {
+		best, selected := math.MaxFloat64, -1
+		for i, result := range results {
+			cost := 0.0
+			for f, c := range result.FoldCosts {
+				if f != fold {
+					cost += c
+				}
+			}
+			if cost < best {
+				best, selected = cost, i
+			}
+		}
+		if selected >= 0 {
+			results[selected].Selected++
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].MeanCost < results[j].MeanCost
+	})
+	return results, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCrossValidate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	instances := make([]*Instance, 20)
+	for i := range instances {
+		instances[i] = &Instance{Name: fmt.Sprintf("random%d", i), Size: 8, Dist: randomInstance(rng, 8)}
+	}
+	var grid []string
+	for _, temperature := range []float64{1, 50} {
+		for _, cooling := range []float64{.9, .999} {
+			grid = append(grid, fmt.Sprintf(`{"method": "sa", "initial_temp": %g, "cooling": %g, "iterations": 2000, "init_method": "random"}`,
+				temperature, cooling))
+		}
+	}
+	results, err := CrossValidate(instances, grid, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(grid) {
+		t.Fatalf("Expected %d results, got %d", len(grid), len(results))
+	}
+	selected := 0
+	for i, result := range results {
+		if i > 0 && result.MeanCost < results[i-1].MeanCost {
+			t.Errorf("Expected the results sorted by mean cost, got %f after %f", result.MeanCost, results[i-1].MeanCost)
+		}
+		mean := 0.0
+		for _, cost := range result.FoldCosts {
+			mean += cost / 4
+		}
+		if math.Abs(mean-result.MeanCost) > 1e-9 {
+			t.Errorf("Expected the mean of the folds %f to be the mean cost %f", mean, result.MeanCost)
+		}
+		selected += result.Selected
+	}
+	if selected != 4 {
+		t.Errorf("Expected a configuration to be selected for each of the 4 folds, got %d", selected)
+	}
+	if results[0].Selected == 0 {
+		t.Errorf("Expected the best configuration %s to be selected", results[0].Config)
+	}
+	if _, err := CrossValidate(instances, grid, 1); err == nil {
+		t.Error("Expected an error for 1 fold")
+	}
+	if _, err := CrossValidate(instances, []string{`{"method": "sa", "cooling": "fast"}`}, 4); err == nil {
+		t.Error("Expected an error for an invalid configuration")
+	}
+}
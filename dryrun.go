@@ -0,0 +1,119 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// operationTime is the estimated time of one operation in seconds, it is
+	// about the time Search spends per complete tour on 8 cities
+	operationTime = 150e-9
+	// slowEstimate is the estimated runtime in seconds above which a solver
+	// is flagged as slow
+	slowEstimate = 60
+)
+
+// SolverPlan is the configuration of a solver on an instance
+type SolverPlan struct {
+	Name string `json:"name"`
+	// Complexity is the time complexity of the solver in the number of
+	// cities n
+	Complexity string `json:"complexity"`
+	// Estimate is the estimated runtime in seconds
+	Estimate float64 `json:"estimate"`
+	// Options are the options the solver runs with
+	Options interface{} `json:"options,omitempty"`
+}
+
+// factorial computes n! as a float so it doesn't overflow
+func factorial(n int) float64 {
+	f := 1.0
+	for i := 2; i <= n; i++ {
+		f *= float64(i)
+	}
+	return f
+}
+
+// PlanSolvers returns the configuration of the default solvers on the
+// instance, sorted by name
+func PlanSolvers(dist []float64, size int) []SolverPlan {
+	n := float64(size)
+	tabu := defaultTabuOptions(size, solverSeed(dist, size, "tabu"))
+	ils := defaultILSOptions(size, solverSeed(dist, size, "ils"))
+	mcts := defaultMCTSOptions(size, solverSeed(dist, size, "mcts"))
+	plans := map[string]SolverPlan{
+		"search":          {Estimate: factorial(size)},
+		"pagerank":        {Estimate: n * n},
+		"eigen":           {Estimate: n * n * n, Options: flagEigenOptions()},
+		"eigen2":          {Estimate: n * n * n},
+		"nearestneighbor": {Estimate: n * n * n},
+		"neural2":         {Estimate: 1024 * n * n * n * n},
+		"tabu":            {Estimate: float64(tabu.Iterations) * n * n * n, Options: tabu},
+		"ils":             {Estimate: float64(ils.Iterations) * n * n * n * n, Options: ils},
+		"twoopt":          {Estimate: n * n * n * n},
+		"beam":            {Estimate: n * n * n * n * n, Options: map[string]int{"width": size}},
+		"savings":         {Estimate: n * n * math.Log2(n+1), Options: map[string]int{"depot": 0}},
+		"christofides":    {Estimate: n * n * n},
+		"mcts":            {Estimate: float64(mcts.Iterations) * n * n, Options: mcts},
+	}
+	solvers := SizedSolvers(DefaultSolvers(), size)
+	result := make([]SolverPlan, 0, len(solvers))
+	for name := range solvers {
+		plan := plans[name]
+		plan.Name = name
+		plan.Complexity = SolverInfos[name].Complexity
+		plan.Estimate *= operationTime
+		result = append(result, plan)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// formatEstimate formats an estimated runtime in seconds
+func formatEstimate(seconds float64) string {
+	if seconds < 1e9 {
+		return time.Duration(seconds * float64(time.Second)).Round(time.Microsecond).String()
+	}
+	return fmt.Sprintf("%.3gs", seconds)
+}
+
+// DryRun writes the size of the instance and the configuration of each solver
+// without solving it, solvers estimated to take more than a minute are
+// flagged with a warning
+func DryRun(w io.Writer, instance *Instance) error {
+	_, err := fmt.Fprintf(w, "instance %s with %d cities\n", instance.Name, instance.Size)
+	if err != nil {
+		return err
+	}
+	for _, plan := range PlanSolvers(instance.Dist, instance.Size) {
+		options := []byte("-")
+		if plan.Options != nil {
+			options, err = json.Marshal(plan.Options)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintf(w, "%-16s %-18s %14s %s\n", plan.Name, plan.Complexity, formatEstimate(plan.Estimate), options)
+		if err != nil {
+			return err
+		}
+		if plan.Estimate > slowEstimate {
+			_, err = fmt.Fprintf(w, "warning: %s is expected to take %s\n", plan.Name, formatEstimate(plan.Estimate))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	instance := MustTestInstance("canonical4")
+	var buffer bytes.Buffer
+	if err := DryRun(&buffer, &instance); err != nil {
+		t.Fatal(err)
+	}
+	output := buffer.String()
+	if !strings.HasPrefix(output, "instance canonical4 with 4 cities\n") {
+		t.Errorf("Expected the instance size first, got %q", output)
+	}
+	if strings.Contains(output, "warning") {
+		t.Errorf("Expected no warnings for 4 cities, got %q", output)
+	}
+	if !strings.Contains(output, `"Iterations":400`) {
+		t.Errorf("Expected the tabu options, got %q", output)
+	}
+
+	plans := PlanSolvers(make([]float64, 14*14), 14)
+	if len(plans) != len(SizedSolvers(DefaultSolvers(), 14)) {
+		t.Errorf("Expected a plan for each solver, got %d", len(plans))
+	}
+	for _, plan := range plans {
+		if plan.Name == "search" {
+			t.Error("Expected search to require Size cities")
+		}
+		if plan.Complexity == "" {
+			t.Errorf("Expected a complexity for %s", plan.Name)
+		}
+	}
+	large := Instance{Name: "large", Size: 64}
+	buffer.Reset()
+	if err := DryRun(&buffer, &large); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buffer.String(), "warning:") {
+		t.Errorf("Expected a warning for 64 cities, got %q", buffer.String())
+	}
+}
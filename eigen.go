@@ -0,0 +1,171 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// EigenAll selects all of the eigenvalues
+	EigenAll = "all"
+	// EigenTopK selects the K eigenvalues of largest magnitude
+	EigenTopK = "top-k"
+	// EigenBottomK selects the K eigenvalues of smallest magnitude
+	EigenBottomK = "bottom-k"
+	// EigenRealOnly selects the eigenvalues without an imaginary part
+	EigenRealOnly = "real-only"
+)
+
+const (
+	// ComponentReal uses the real part of the scaled eigenvectors
+	ComponentReal = "real"
+	// ComponentImag uses the imaginary part of the scaled eigenvectors
+	ComponentImag = "imag"
+	// ComponentComplex uses the modulus of the scaled eigenvectors
+	ComponentComplex = "complex"
+)
+
+const (
+	// CombineBest takes the better of the right and left eigenvector tours
+	CombineBest = "best"
+	// CombineAverage averages the right and left eigenvector distances
+	CombineAverage = "average"
+	// CombineConcat concatenates the right and left eigenvector embeddings
+	CombineConcat = "concat"
+)
+
+// EigenOptions are the options for Eigen
+type EigenOptions struct {
+	// SelectionStrategy selects the eigenvalues used in the distance
+	// computation, one of EigenAll, EigenTopK, EigenBottomK or EigenRealOnly
+	SelectionStrategy string
+	// K is the number of eigenvalues selected by EigenTopK and EigenBottomK
+	K int
+	// Component is the component of the difference between the scaled
+	// eigenvectors of two cities used in their distance, one of
+	// ComponentReal, ComponentImag or ComponentComplex
+	Component string
+	// NormalizeVectors L2 normalizes each eigenvector before computing the
+	// distances
+	NormalizeVectors bool
+	// CombineVectors is how the right and left eigenvectors are combined, one
+	// of CombineBest, CombineAverage or CombineConcat
+	CombineVectors string
+}
+
+// Validate returns an error if the options are invalid
+func (o EigenOptions) Validate() error {
+	if _, err := SelectEigenvalues(nil, o); err != nil {
+		return err
+	}
+	switch o.Component {
+	case "", ComponentReal, ComponentImag, ComponentComplex:
+	default:
+		return fmt.Errorf("unknown eigenvector component %q", o.Component)
+	}
+	switch o.CombineVectors {
+	case "", CombineBest, CombineAverage, CombineConcat:
+	default:
+		return fmt.Errorf("unknown eigenvector combination %q", o.CombineVectors)
+	}
+	return nil
+}
+
+// part returns the function that maps a complex difference to the component
+// used in the distance
+func (o EigenOptions) part() func(complex128) float64 {
+	switch o.Component {
+	case ComponentImag:
+		return func(x complex128) float64 { return imag(x) }
+	case ComponentComplex:
+		return cmplx.Abs
+	}
+	return func(x complex128) float64 { return real(x) }
+}
+
+// SelectEigenvalues returns the indexes of the eigenvalues selected by the
+// options
+func SelectEigenvalues(values []complex128, options EigenOptions) ([]int, error) {
+	indexes := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	switch options.SelectionStrategy {
+	case "", EigenAll:
+		return indexes, nil
+	case EigenTopK, EigenBottomK:
+		if options.K < 1 {
+			return nil, fmt.Errorf("%s needs k > 0, got %d", options.SelectionStrategy, options.K)
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			a, b := cmplx.Abs(values[indexes[i]]), cmplx.Abs(values[indexes[j]])
+			if options.SelectionStrategy == EigenTopK {
+				return a > b
+			}
+			return a < b
+		})
+		if options.K < len(indexes) {
+			indexes = indexes[:options.K]
+		}
+		return indexes, nil
+	case EigenRealOnly:
+		selected := indexes[:0]
+		for _, i := range indexes {
+			if imag(values[i]) == 0 {
+				selected = append(selected, i)
+			}
+		}
+		return selected, nil
+	}
+	return nil, fmt.Errorf("unknown eigenvalue selection strategy %q", options.SelectionStrategy)
+}
+
+// normalizeColumns scales each column of m to unit L2 norm, zero columns are
+// left as they are
+func normalizeColumns(m *mat.CDense) {
+	r, c := m.Dims()
+	for j := 0; j < c; j++ {
+		sum := 0.0
+		for i := 0; i < r; i++ {
+			abs := cmplx.Abs(m.At(i, j))
+			sum += abs * abs
+		}
+		if sum == 0 {
+			continue
+		}
+		norm := complex(math.Sqrt(sum), 0)
+		for i := 0; i < r; i++ {
+			m.Set(i, j, m.At(i, j)/norm)
+		}
+	}
+}
+
+// combineDistances combines the right and left eigenvector distances, the
+// combined distances are returned for both. Both distances are the norm of
+// the embedding difference scaled by the same cost, so the distance of the
+// concatenated embeddings is their hypotenuse.
+func combineDistances(right, left []float64, combine string) ([]float64, []float64) {
+	switch combine {
+	case CombineAverage:
+		combined := make([]float64, len(right))
+		for i := range combined {
+			combined[i] = (right[i] + left[i]) / 2
+		}
+		return combined, combined
+	case CombineConcat:
+		combined := make([]float64, len(right))
+		for i := range combined {
+			combined[i] = math.Hypot(right[i], left[i])
+		}
+		return combined, combined
+	}
+	return right, left
+}
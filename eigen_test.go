@@ -0,0 +1,113 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSelectEigenvalues(t *testing.T) {
+	values := []complex128{2, complex(1, 1), complex(1, -1), -3}
+	tests := []struct {
+		options  EigenOptions
+		expected []int
+	}{
+		{EigenOptions{SelectionStrategy: EigenAll}, []int{0, 1, 2, 3}},
+		{EigenOptions{SelectionStrategy: EigenTopK, K: 2}, []int{3, 0}},
+		{EigenOptions{SelectionStrategy: EigenBottomK, K: 2}, []int{1, 2}},
+		{EigenOptions{SelectionStrategy: EigenTopK, K: 8}, []int{3, 0, 1, 2}},
+		{EigenOptions{SelectionStrategy: EigenRealOnly}, []int{0, 3}},
+	}
+	for _, test := range tests {
+		selected, err := SelectEigenvalues(values, test.options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(selected, test.expected) {
+			t.Errorf("Expected %v for %+v, got %v", test.expected, test.options, selected)
+		}
+	}
+	if _, err := SelectEigenvalues(values, EigenOptions{SelectionStrategy: EigenTopK}); err == nil {
+		t.Error("Expected an error for k = 0")
+	}
+	if _, err := SelectEigenvalues(values, EigenOptions{SelectionStrategy: "random"}); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
+
+func TestEigenWithOptions(t *testing.T) {
+	_, cost, route := EigenWithOptions(canonical, EigenOptions{SelectionStrategy: EigenTopK, K: 2})
+	if err := ValidateTour(route, Size); err != nil {
+		t.Fatal(err)
+	}
+	if expected := TourCost(canonical, Size, route); cost != expected {
+		t.Errorf("Expected cost %f, got %f", expected, cost)
+	}
+}
+
+func TestEigenComponent(t *testing.T) {
+	for _, component := range []string{ComponentReal, ComponentImag, ComponentComplex} {
+		_, cost, route := EigenWithOptions(canonical, EigenOptions{Component: component})
+		if err := ValidateTour(route, Size); err != nil {
+			t.Fatalf("%s: %v", component, err)
+		}
+		if expected := TourCost(canonical, Size, route); cost != expected {
+			t.Errorf("Expected %s cost %f, got %f", component, expected, cost)
+		}
+	}
+	if err := (EigenOptions{Component: "phase"}).Validate(); err == nil {
+		t.Error("Expected an error for an unknown component")
+	}
+}
+
+func TestNormalizeColumns(t *testing.T) {
+	m := mat.NewCDense(2, 2, []complex128{3, 0, complex(0, 4), 0})
+	normalizeColumns(m)
+	expected := mat.NewCDense(2, 2, []complex128{.6, 0, complex(0, .8), 0})
+	if !mat.CEqualApprox(m, expected, 1e-12) {
+		t.Errorf("Expected %v, got %v", expected.RawCMatrix().Data, m.RawCMatrix().Data)
+	}
+}
+
+func TestEigenNormalizeEqualDistances(t *testing.T) {
+	a := make([]float64, Size*Size)
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			if i != j {
+				a[i*Size+j] = 1
+			}
+		}
+	}
+	_, raw, rawRoute := EigenWithOptions(a, EigenOptions{})
+	_, normalized, normalizedRoute := EigenWithOptions(a, EigenOptions{NormalizeVectors: true})
+	if raw != normalized || !reflect.DeepEqual(rawRoute, normalizedRoute) {
+		t.Errorf("Expected %f %v, got %f %v", raw, rawRoute, normalized, normalizedRoute)
+	}
+}
+
+func TestCombineDistances(t *testing.T) {
+	right, left := []float64{0, 3, 2}, []float64{0, 4, 4}
+	tests := []struct {
+		combine     string
+		right, left []float64
+	}{
+		{CombineBest, []float64{0, 3, 2}, []float64{0, 4, 4}},
+		{CombineAverage, []float64{0, 3.5, 3}, []float64{0, 3.5, 3}},
+		{CombineConcat, []float64{0, 5, math.Sqrt(20)}, []float64{0, 5, math.Sqrt(20)}},
+	}
+	for _, test := range tests {
+		r, l := combineDistances(right, left, test.combine)
+		if !reflect.DeepEqual(r, test.right) || !reflect.DeepEqual(l, test.left) {
+			t.Errorf("Expected %s %v %v, got %v %v", test.combine, test.right, test.left, r, l)
+		}
+	}
+	if err := (EigenOptions{CombineVectors: "sum"}).Validate(); err == nil {
+		t.Error("Expected an error for an unknown combination")
+	}
+}
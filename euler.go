@@ -0,0 +1,100 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EulerianCircuit finds a circuit using every edge of the undirected
+// multigraph exactly once with Hierholzer's algorithm. Every edge {u, v} must
+// appear in both adj[u] and adj[v]. An error is returned if a vertex has odd
+// degree or the edges are not connected.
+func EulerianCircuit(adj [][]int, size int) ([]int, error) {
+	type Pair struct {
+		From, To int
+	}
+	counts := make(map[Pair]int)
+	start, total := -1, 0
+	for v := 0; v < size; v++ {
+		if len(adj[v])%2 == 1 {
+			return nil, fmt.Errorf("vertex %d has odd degree %d", v, len(adj[v]))
+		}
+		if start == -1 && len(adj[v]) > 0 {
+			start = v
+		}
+		total += len(adj[v])
+		for _, u := range adj[v] {
+			counts[Pair{From: v, To: u}]++
+		}
+	}
+	if start == -1 {
+		return nil, errors.New("graph has no edges")
+	}
+
+	edges := make([]Pair, 0, total/2)
+	incident := make([][]int, size)
+	for v := 0; v < size; v++ {
+		for _, u := range adj[v] {
+			if u < v {
+				continue
+			}
+			count := counts[Pair{From: v, To: u}]
+			if count == 0 {
+				continue
+			} else if u == v {
+				count /= 2
+			} else if count != counts[Pair{From: u, To: v}] {
+				return nil, fmt.Errorf("edge %d-%d is missing from vertex %d", v, u, u)
+			}
+			for ; count > 0; count-- {
+				incident[v] = append(incident[v], len(edges))
+				incident[u] = append(incident[u], len(edges))
+				edges = append(edges, Pair{From: v, To: u})
+			}
+			counts[Pair{From: v, To: u}] = 0
+		}
+	}
+
+	used, next := make([]bool, len(edges)), make([]int, size)
+	stack, circuit := []int{start}, make([]int, 0, len(edges)+1)
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		for next[v] < len(incident[v]) && used[incident[v][next[v]]] {
+			next[v]++
+		}
+		if next[v] == len(incident[v]) {
+			circuit = append(circuit, v)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		e := incident[v][next[v]]
+		used[e] = true
+		u := edges[e].From
+		if u == v {
+			u = edges[e].To
+		}
+		stack = append(stack, u)
+	}
+	if len(circuit) != len(edges)+1 {
+		return nil, errors.New("graph is not connected")
+	}
+	return circuit, nil
+}
+
+// Shortcut turns a circuit visiting every city into a tour by skipping cities
+// that have already been visited
+func Shortcut(circuit []int, size int) []int {
+	visited := make([]bool, size)
+	route := make([]int, 0, size+1)
+	for _, v := range circuit {
+		if !visited[v] {
+			visited[v] = true
+			route = append(route, v)
+		}
+	}
+	return append(route, route[0])
+}
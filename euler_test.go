@@ -0,0 +1,93 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestEulerianCircuit(t *testing.T) {
+	// two triangles sharing vertex 0, and a doubled edge
+	adj := [][]int{
+		{1, 2, 3, 4},
+		{0, 2},
+		{0, 1},
+		{0, 4},
+		{0, 3, 5, 5},
+		{4, 4},
+	}
+	circuit, err := EulerianCircuit(adj, 6)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(circuit) != 9 {
+		t.Fatalf("Expected 9 vertices in the circuit, got %v", circuit)
+	}
+	if circuit[0] != circuit[len(circuit)-1] {
+		t.Errorf("Expected the circuit to be closed, got %v", circuit)
+	}
+	used := make(map[[2]int]int)
+	for i := 1; i < len(circuit); i++ {
+		used[pair(circuit[i-1], circuit[i])]++
+	}
+	expected := map[[2]int]int{
+		{0, 1}: 1, {1, 2}: 1, {0, 2}: 1, {0, 3}: 1, {3, 4}: 1, {0, 4}: 1, {4, 5}: 2,
+	}
+	for edge, count := range expected {
+		if used[edge] != count {
+			t.Errorf("Expected edge %v to be used %d times, got %d", edge, count, used[edge])
+		}
+	}
+}
+
+func TestEulerianCircuitErrors(t *testing.T) {
+	graphs := map[string][][]int{
+		"odd degree": {
+			{1},
+			{0, 2},
+			{1},
+		},
+		"disconnected": {
+			{1, 2},
+			{0, 2},
+			{0, 1},
+			{4, 5},
+			{3, 5},
+			{3, 4},
+		},
+		"no edges": {
+			{},
+			{},
+		},
+	}
+	for name, adj := range graphs {
+		if _, err := EulerianCircuit(adj, len(adj)); err == nil {
+			t.Errorf("Expected an error for %s graph", name)
+		}
+	}
+}
+
+func TestShortcut(t *testing.T) {
+	circuit := []int{0, 1, 2, 0, 4, 5, 4, 3, 0}
+	route := Shortcut(circuit, 6)
+	if len(route) != 7 {
+		t.Fatalf("Expected 7 cities, got %v", route)
+	}
+	if route[0] != route[6] {
+		t.Errorf("Expected the route to be closed, got %v", route)
+	}
+	count := make([]int, 6)
+	for _, v := range route[:6] {
+		count[v]++
+	}
+	for i, c := range count {
+		if c != 1 {
+			t.Errorf("Expected city %d to appear once, got %d", i, c)
+		}
+	}
+	if expected := []int{0, 1, 2, 4, 5, 3, 0}; !equal(route, expected) {
+		t.Errorf("Expected route %v, got %v", expected, route)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// explainer records the decisions of a solve, a nil explainer records nothing
+type explainer struct {
+	steps []string
+}
+
+// enabled is true if the explainer records decisions, the arguments of a
+// decision should only be formatted if it is
+func (e *explainer) enabled() bool {
+	return e != nil
+}
+
+// explain records a decision
+func (e *explainer) explain(format string, a ...interface{}) {
+	if e == nil {
+		return
+	}
+	step := fmt.Sprintf("Step %d: ", len(e.steps)+1)
+	e.steps = append(e.steps, step+fmt.Sprintf(format, a...))
+}
+
+// ExplainedSolver is a solver that records each decision the inner solver
+// makes. Nearest neighbor and TwoOpt explain their decisions when the inner
+// solver passes the explainer of the solve on to them, as the nearestneighbor,
+// ils and twoopt default solvers do. It is safe to solve concurrently, each
+// solve has its own explainer.
+type ExplainedSolver struct {
+	base        Solver
+	mu          sync.Mutex
+	explanation []string
+}
+
+// NewExplainedSolver wraps the solver
+func NewExplainedSolver(base Solver) *ExplainedSolver {
+	return &ExplainedSolver{base: base}
+}
+
+// Solve solves with the inner solver recording its decisions
+func (es *ExplainedSolver) Solve(dist []float64, size int) Tour {
+	var e explainer
+	var tour Tour
+	if base, ok := es.base.(hookedSolver); ok {
+		tour = base.solveHooked(dist, size, hooks{explainer: &e})
+	} else {
+		tour = es.base.Solve(dist, size)
+	}
+	es.mu.Lock()
+	es.explanation = e.steps
+	es.mu.Unlock()
+	return tour
+}
+
+// SupportsWarmStart is false
+func (es *ExplainedSolver) SupportsWarmStart() bool {
+	return false
+}
+
+// Explain returns the decisions made by the last solve to finish in order
+func (es *ExplainedSolver) Explain() []string {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.explanation
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExplainedSolver(t *testing.T) {
+	solver := NewExplainedSolver(hookedFunc(func(dist []float64, size int, h hooks) Tour {
+		return explainedNearestNeighbor(dist, size, 0, h.explainer)
+	}))
+	solver.Solve(canonical, Size)
+	steps := solver.Explain()
+	if len(steps) != Size-1 {
+		t.Fatalf("Expected %d steps, got %v", Size-1, steps)
+	}
+	expected := "Step 1: from city 0, chose city 1 (distance 20.0) over city 3 (distance 35.0)"
+	if steps[0] != expected {
+		t.Errorf("Expected %q, got %q", expected, steps[0])
+	}
+
+	// the default solver starts from every city in turn, starting with city 0
+	solver = NewExplainedSolver(DefaultSolvers()["nearestneighbor"])
+	solver.Solve(canonical, Size)
+	all := solver.Explain()
+	if len(all) != Size*(Size-1) {
+		t.Fatalf("Expected %d steps from every starting city, got %v", Size*(Size-1), all)
+	}
+	for i, step := range steps {
+		if all[i] != step {
+			t.Errorf("Expected %q from city 0, got %q", step, all[i])
+		}
+	}
+	// concurrent solves each record their own steps
+	var solves sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		solves.Add(1)
+		go func() {
+			defer solves.Done()
+			solver.Solve(canonical, Size)
+		}()
+	}
+	solves.Wait()
+	if len(solver.Explain()) != Size*(Size-1) {
+		t.Errorf("Expected %d steps after concurrent solves, got %v", Size*(Size-1), solver.Explain())
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 10)
+	start := append(rng.Perm(10), 0)
+	start[10] = start[0]
+	solver = NewExplainedSolver(DefaultSolvers()["ils"])
+	// solves in other goroutines aren't explained
+	done := make(chan struct{})
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				TwoOpt(dist, 10, start)
+				nearestNeighbor(dist, 10, 0)
+			}
+		}
+	}()
+	solver.Solve(dist, 10)
+	close(done)
+	wait.Wait()
+	steps = solver.Explain()
+	if len(steps) == 0 {
+		t.Fatal("Expected 2-opt moves to be explained")
+	}
+	for _, step := range steps {
+		if !strings.Contains(step, "reversed positions") {
+			t.Errorf("Expected a 2-opt move, got %q", step)
+		}
+	}
+	again := NewExplainedSolver(solver.base)
+	again.Solve(dist, 10)
+	if len(again.Explain()) != len(steps) {
+		t.Errorf("Expected the same %d steps, got %d", len(steps), len(again.Explain()))
+	}
+
+	solver = NewExplainedSolver(SolverFunc(func(dist []float64, size int) Tour {
+		return nearestNeighbor(dist, size, 0)
+	}))
+	solver.Solve(dist, 10)
+	if steps := solver.Explain(); len(steps) != 0 {
+		t.Errorf("Expected no steps from a solver without hooks, got %v", steps)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// decodeConfig decodes the json solver configuration into v, rejecting fields
+// that v doesn't have
+func decodeConfig(config string, v interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(config))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err != nil {
+		return fmt.Errorf("invalid solver configuration %s: %v", config, err)
+	}
+	return nil
+}
+
+// NewSolverFromJSON builds a solver from a json object with the name of the
+// method and its options, such as
+//
+//	{"method": "sa", "initial_temp": 100, "cooling": 0.99, "iterations": 5000}
+//
+// The methods with options are sa, tabu, ils, mcts, beam and savings, options
+// that are left out or zero take the values of the default solvers. The other
+// default solvers are built from just their method.
+func NewSolverFromJSON(config string) (Solver, error) {
+	return newSolverFromJSON(config, nil)
+}
+
+// newSolverFromJSON builds a solver like NewSolverFromJSON, the sa and tabu
+// solvers call improve with the starting tour and each better tour found if
+// improve isn't nil
+func newSolverFromJSON(config string, improve func(Tour)) (Solver, error) {
+	var method struct {
+		Method string `json:"method"`
+	}
+	err := json.Unmarshal([]byte(config), &method)
+	if err != nil {
+		return nil, fmt.Errorf("invalid solver configuration %s: %v", config, err)
+	}
+	switch method.Method {
+	case "sa":
+		c := struct {
+			Method      string  `json:"method"`
+			Iterations  int     `json:"iterations"`
+			InitialTemp float64 `json:"initial_temp"`
+			Cooling     float64 `json:"cooling"`
+			Seed        int64   `json:"seed"`
+			InitMethod  string  `json:"init_method"`
+			// CheckpointInterval and CheckpointPath set the checkpoints of
+			// the run, see SAOptions
+			CheckpointInterval int    `json:"checkpoint_interval"`
+			CheckpointPath     string `json:"checkpoint_path"`
+		}{InitialTemp: 50, Cooling: .999, Seed: 1, InitMethod: InitNN}
+		if err := decodeConfig(config, &c); err != nil {
+			return nil, err
+		}
+		if c.CheckpointInterval > 0 && c.CheckpointPath == "" {
+			return nil, fmt.Errorf("invalid solver configuration %s: checkpoint_interval requires checkpoint_path", config)
+		}
+		return warmHookedFunc(func(dist []float64, size int, tour []int, h hooks) Tour {
+			opts := SAOptions{
+				Iterations:         c.Iterations,
+				Temperature:        c.InitialTemp,
+				Cooling:            c.Cooling,
+				Seed:               c.Seed,
+				InitMethod:         c.InitMethod,
+				CheckpointInterval: c.CheckpointInterval,
+				CheckpointPath:     c.CheckpointPath,
+				OnImprove:          improve,
+			}
+			if opts.Iterations == 0 {
+				opts.Iterations = 1000 * size
+			}
+			return simulatedAnnealing(dist, size, tour, opts, h)
+		}), nil
+	case "tabu":
+		c := struct {
+			Method     string `json:"method"`
+			Iterations int    `json:"iterations"`
+			Tenure     int    `json:"tenure"`
+			Seed       int64  `json:"seed"`
+			InitMethod string `json:"init_method"`
+		}{Seed: 1}
+		if err := decodeConfig(config, &c); err != nil {
+			return nil, err
+		}
+		return WarmSolverFunc(func(dist []float64, size int, tour []int) Tour {
+			opts := defaultTabuOptions(size, c.Seed)
+			if c.Iterations != 0 {
+				opts.Iterations = c.Iterations
+			}
+			if c.Tenure != 0 {
+				opts.Tenure = c.Tenure
+			}
+			opts.InitMethod, opts.Tour, opts.OnImprove = c.InitMethod, tour, improve
+			return TabuSearch(dist, size, opts)
+		}), nil
+	case "ils":
+		c := struct {
+			Method      string `json:"method"`
+			Iterations  int    `json:"iterations"`
+			Seed        int64  `json:"seed"`
+			PerturbType string `json:"perturb_type"`
+			Steps       int    `json:"steps"`
+		}{Seed: 1}
+		if err := decodeConfig(config, &c); err != nil {
+			return nil, err
+		}
+		return hookedFunc(func(dist []float64, size int, h hooks) Tour {
+			opts := defaultILSOptions(size, c.Seed)
+			if c.Iterations != 0 {
+				opts.Iterations = c.Iterations
+			}
+			opts.PerturbType, opts.Steps = c.PerturbType, c.Steps
+			return iteratedLocalSearch(dist, size, opts, h)
+		}), nil
+	case "mcts":
+		c := struct {
+			Method           string  `json:"method"`
+			Iterations       int     `json:"iterations"`
+			ExplorationConst float64 `json:"exploration_const"`
+			Seed             int64   `json:"seed"`
+		}{Seed: 1}
+		if err := decodeConfig(config, &c); err != nil {
+			return nil, err
+		}
+		return SolverFunc(func(dist []float64, size int) Tour {
+			opts := defaultMCTSOptions(size, c.Seed)
+			if c.Iterations != 0 {
+				opts.Iterations = c.Iterations
+			}
+			if c.ExplorationConst != 0 {
+				opts.ExplorationConst = c.ExplorationConst
+			}
+			return MonteCarloTreeSearch(dist, size, opts)
+		}), nil
+	case "beam":
+		c := struct {
+			Method string `json:"method"`
+			Width  int    `json:"width"`
+		}{}
+		if err := decodeConfig(config, &c); err != nil {
+			return nil, err
+		}
+		return SolverFunc(func(dist []float64, size int) Tour {
+			width := c.Width
+			if width == 0 {
+				width = size
+			}
+			return BeamSearch(dist, size, width)
+		}), nil
+	case "savings":
+		c := struct {
+			Method string `json:"method"`
+			Depot  int    `json:"depot"`
+		}{}
+		if err := decodeConfig(config, &c); err != nil {
+			return nil, err
+		}
+		return SolverFunc(func(dist []float64, size int) Tour {
+			return SavingsAlgorithm(dist, size, c.Depot)
+		}).symmetric(), nil
+	}
+	solver, ok := DefaultSolvers()[method.Method]
+	if !ok {
+		return nil, fmt.Errorf("unknown solver method %q", method.Method)
+	}
+	if err := decodeConfig(config, &method); err != nil {
+		return nil, err
+	}
+	return solver, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewSolverFromJSON(t *testing.T) {
+	configs := []string{
+		`{"method": "sa", "initial_temp": 100, "cooling": 0.99, "iterations": 5000}`,
+		`{"method": "tabu", "iterations": 50, "tenure": 2, "init_method": "greedy"}`,
+		`{"method": "ils", "perturb_type": "random_walk", "steps": 3}`,
+		`{"method": "mcts", "exploration_const": 2}`,
+		`{"method": "beam", "width": 2}`,
+		`{"method": "savings", "depot": 1}`,
+	}
+	for name := range DefaultSolvers() {
+		configs = append(configs, fmt.Sprintf(`{"method": %q}`, name))
+	}
+	for _, config := range configs {
+		solver, err := NewSolverFromJSON(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tour := solver.Solve(canonical, Size)
+		if err := VerifyTour(canonical, Size, tour); err != nil {
+			t.Errorf("%s: %v", config, err)
+		}
+	}
+
+	errors := []struct {
+		config, message string
+	}{
+		{`{"method": "sa", "cooling": }`, "invalid solver configuration"},
+		{`{"method": "sa", "temperature": 100}`, `unknown field "temperature"`},
+		{`{"method": "eigen", "seed": 1}`, `unknown field "seed"`},
+		{`{"method": "heldkarp"}`, `unknown solver method "heldkarp"`},
+	}
+	for _, test := range errors {
+		_, err := NewSolverFromJSON(test.config)
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("Expected an error containing %q for %s, got %v", test.message, test.config, err)
+		}
+	}
+}
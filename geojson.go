@@ -0,0 +1,73 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// GeoCity is a city at a latitude and a longitude in degrees
+type GeoCity struct {
+	Name string
+	Lat  float64
+	Lon  float64
+}
+
+// geoJSONGeometry is a GeoJSON geometry, the positions are longitude and
+// latitude
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// geoJSONFeature is a GeoJSON feature
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJSONFeatureCollection is a GeoJSON feature collection
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// WriteTourGeoJSON writes the cities and the tour as a GeoJSON feature
+// collection with a Point feature for each city, followed by a LineString
+// feature for the tour, that GIS tools can display on a map
+func WriteTourGeoJSON(w io.Writer, cities []GeoCity, tour []int) error {
+	if err := ValidateTour(tour, len(cities)); err != nil {
+		return err
+	}
+	collection := geoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]geoJSONFeature, 0, len(cities)+1),
+	}
+	for i, city := range cities {
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONGeometry{
+				Type:        "Point",
+				Coordinates: [2]float64{city.Lon, city.Lat},
+			},
+			Properties: map[string]interface{}{"city": i, "name": city.Name},
+		})
+	}
+	path := make([][2]float64, len(tour))
+	for i, city := range tour {
+		path[i] = [2]float64{cities[city].Lon, cities[city].Lat}
+	}
+	collection.Features = append(collection.Features, geoJSONFeature{
+		Type: "Feature",
+		Geometry: geoJSONGeometry{
+			Type:        "LineString",
+			Coordinates: path,
+		},
+		Properties: map[string]interface{}{"route": tour},
+	})
+	return json.NewEncoder(w).Encode(collection)
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteTourGeoJSON(t *testing.T) {
+	cities := []GeoCity{
+		{"Paris", 48.8566, 2.3522},
+		{"Berlin", 52.52, 13.405},
+		{"Vienna", 48.2082, 16.3738},
+		{"Rome", 41.9028, 12.4964},
+		{"Madrid", 40.4168, -3.7038},
+	}
+	var buffer bytes.Buffer
+	if err := WriteTourGeoJSON(&buffer, cities, []int{0, 1, 2, 3, 4, 0}); err != nil {
+		t.Fatal(err)
+	}
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(buffer.Bytes(), &collection); err != nil {
+		t.Fatalf("Expected json, got %s: %v", buffer.String(), err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != len(cities)+1 {
+		t.Fatalf("Expected a FeatureCollection of %d features, got %s", len(cities)+1, buffer.String())
+	}
+	for i, feature := range collection.Features[:len(cities)] {
+		var point [2]float64
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &point); err != nil {
+			t.Fatal(err)
+		}
+		if feature.Type != "Feature" || feature.Geometry.Type != "Point" || point != [2]float64{cities[i].Lon, cities[i].Lat} {
+			t.Errorf("Expected a Point at %f %f for %s, got %s %v", cities[i].Lon, cities[i].Lat, cities[i].Name, feature.Geometry.Type, point)
+		}
+	}
+	line := collection.Features[len(cities)]
+	var path [][2]float64
+	if err := json.Unmarshal(line.Geometry.Coordinates, &path); err != nil {
+		t.Fatal(err)
+	}
+	if line.Geometry.Type != "LineString" || len(path) != 6 || path[0] != path[5] {
+		t.Errorf("Expected a closed LineString of 6 positions, got %s %v", line.Geometry.Type, path)
+	}
+	if err := WriteTourGeoJSON(&buffer, cities, []int{0, 1, 2, 0}); err == nil {
+		t.Error("Expected an error for an invalid tour")
+	}
+}
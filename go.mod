@@ -2,6 +2,16 @@ module github.com/pointlander/salesman
 
 go 1.17
 
+require (
+	github.com/lucasb-eyer/go-colorful v1.0.2
+	github.com/muesli/clusters v0.0.0-20200529215643-2700303c1762
+	github.com/pointlander/gradient v0.0.0-20201206051041-dbff480e6d28
+	github.com/pointlander/pagerank v0.0.0-20210619221740-830548a59275
+	github.com/wcharczuk/go-chart/v2 v2.1.0
+	gonum.org/v1/gonum v0.11.0
+	gonum.org/v1/plot v0.11.0
+)
+
 require (
 	git.sr.ht/~sbinet/gg v0.3.1 // indirect
 	github.com/ajstarks/svgo v0.0.0-20211024235047-1546f124cd8b // indirect
@@ -10,14 +20,9 @@ require (
 	github.com/go-pdf/fpdf v0.6.0 // indirect
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
 	github.com/golang/protobuf v1.4.2 // indirect
-	github.com/muesli/clusters v0.0.0-20200529215643-2700303c1762 // indirect
 	github.com/muesli/kmeans v0.3.0 // indirect
-	github.com/pointlander/gradient v0.0.0-20201206051041-dbff480e6d28 // indirect
-	github.com/pointlander/pagerank v0.0.0-20210619221740-830548a59275 // indirect
 	github.com/ziutek/blas v0.0.0-20190227122918-da4ca23e90bb // indirect
 	golang.org/x/image v0.0.0-20220302094943-723b81ca9867 // indirect
 	golang.org/x/text v0.3.7 // indirect
-	gonum.org/v1/gonum v0.11.0 // indirect
-	gonum.org/v1/plot v0.11.0 // indirect
 	google.golang.org/protobuf v1.24.0 // indirect
 )
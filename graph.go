@@ -0,0 +1,100 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sort"
+)
+
+// Edge is a directed edge between two cities
+type Edge struct {
+	From, To int
+	Weight   float64
+}
+
+// WeightedEdge is an edge of a graph given as an edge list
+type WeightedEdge = Edge
+
+// FromAdjacencyList builds the distance matrix of the graph with size cities
+// given by the directed edges, the distance between cities without an edge is
+// missingWeight, such as math.Inf(1) or a large constant. Undirected graphs
+// need an edge in both directions.
+func FromAdjacencyList(edges []WeightedEdge, size int, missingWeight float64) []float64 {
+	dist := make([]float64, size*size)
+	for i := range dist {
+		if i/size != i%size {
+			dist[i] = missingWeight
+		}
+	}
+	for _, edge := range edges {
+		dist[edge.From*size+edge.To] = edge.Weight
+	}
+	return dist
+}
+
+// NearestNeighborGraph builds a graph containing the k cheapest outgoing edges
+// of each city
+func NearestNeighborGraph(dist []float64, size, k int) []Edge {
+	if k > size-1 {
+		k = size - 1
+	}
+	graph := make([]Edge, 0, size*k)
+	for i := 0; i < size; i++ {
+		edges := make([]Edge, 0, size-1)
+		for j := 0; j < size; j++ {
+			if i == j {
+				continue
+			}
+			edges = append(edges, Edge{
+				From:   i,
+				To:     j,
+				Weight: dist[i*size+j],
+			})
+		}
+		sort.SliceStable(edges, func(i, j int) bool {
+			return edges[i].Weight < edges[j].Weight
+		})
+		graph = append(graph, edges[:k]...)
+	}
+	return graph
+}
+
+// TourFromNNGraph builds a tour from city 0 by following the cheapest edge
+// to an unvisited city, if every neighbor has been visited the lowest
+// numbered unvisited city is used instead
+func TourFromNNGraph(g []Edge, size int) []int {
+	neighbors := make([][]Edge, size)
+	for _, edge := range g {
+		neighbors[edge.From] = append(neighbors[edge.From], edge)
+	}
+	visited := make([]bool, size)
+	state := 0
+	visited[state] = true
+	route := make([]int, 0, size+1)
+	route = append(route, state)
+	for i := 0; i < size-1; i++ {
+		next, min := -1, 0.0
+		for _, edge := range neighbors[state] {
+			if visited[edge.To] {
+				continue
+			}
+			if next == -1 || edge.Weight < min {
+				next, min = edge.To, edge.Weight
+			}
+		}
+		if next == -1 {
+			for j, v := range visited {
+				if !v {
+					next = j
+					break
+				}
+			}
+		}
+		state = next
+		visited[state] = true
+		route = append(route, state)
+	}
+	return append(route, route[0])
+}
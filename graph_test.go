@@ -0,0 +1,54 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestNearestNeighborGraph(t *testing.T) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 10)
+	for k := 1; k < 10; k++ {
+		graph := NearestNeighborGraph(dist, 10, k)
+		if len(graph) != 10*k {
+			t.Errorf("Expected %d edges, got %d", 10*k, len(graph))
+		}
+		for _, edge := range graph {
+			if edge.From == edge.To {
+				t.Errorf("Unexpected self loop at %d", edge.From)
+			}
+		}
+		if err := ValidateTour(TourFromNNGraph(graph, 10), 10); err != nil {
+			t.Errorf("Invalid tour for k=%d: %v", k, err)
+		}
+	}
+	route := TourFromNNGraph(NearestNeighborGraph(dist, 10, 9), 10)
+	if nn := nearestNeighbor(dist, 10, 0); !equal(route, nn.Route) {
+		t.Errorf("Expected the full graph to give nearest neighbor %v, got %v", nn.Route, route)
+	}
+}
+
+func TestFromAdjacencyList(t *testing.T) {
+	triangle := []WeightedEdge{
+		{From: 0, To: 1, Weight: 3},
+		{From: 1, To: 0, Weight: 3},
+		{From: 1, To: 2, Weight: 4},
+		{From: 2, To: 1, Weight: 4},
+		{From: 2, To: 0, Weight: 5},
+	}
+	inf := math.Inf(1)
+	expected := []float64{
+		0, 3, inf,
+		3, 0, 4,
+		5, 4, 0,
+	}
+	dist := FromAdjacencyList(triangle, 3, inf)
+	if !reflect.DeepEqual(dist, expected) {
+		t.Errorf("Expected %v, got %v", expected, dist)
+	}
+}
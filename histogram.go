@@ -0,0 +1,57 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// HistogramNames are the file name suffixes of the cost histogram of each
+// algorithm in the results
+var HistogramNames = []string{"pagerank", "eigen", "eigen2", "nn", "neural2", "nn_pca", "hits", "laplacian", "pagerank_nn", "eigen_nn"}
+
+// PlotCostHistogram plots the distribution of the costs found by the named
+// algorithm as a histogram with the given number of bins
+func PlotCostHistogram(name string, costs []float64, bins int, path string) error {
+	if bins < 1 {
+		return fmt.Errorf("%d bins", bins)
+	}
+	values := make(plotter.Values, len(costs))
+	copy(values, costs)
+
+	p := plot.New()
+
+	p.Title.Text = fmt.Sprintf("%s cost distribution", name)
+	p.X.Label.Text = "cost"
+	p.Y.Label.Text = "trials"
+
+	histogram, err := plotter.NewHist(values, bins)
+	if err != nil {
+		return err
+	}
+	p.Add(histogram)
+
+	return p.Save(8*vg.Inch, 8*vg.Inch, path)
+}
+
+// PlotCostHistograms plots the cost histogram of each algorithm over the
+// trials to histogram_<name>.png
+func PlotCostHistograms(results []TestResult, bins int) error {
+	for i, algorithm := range Algorithms {
+		costs := make([]float64, len(results))
+		for j, result := range results {
+			costs[j] = result.Costs[i]
+		}
+		path := fmt.Sprintf("histogram_%s.png", HistogramNames[i])
+		if err := PlotCostHistogram(algorithm, costs, bins, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
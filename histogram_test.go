@@ -0,0 +1,28 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlotCostHistogram(t *testing.T) {
+	if len(HistogramNames) != len(Algorithms) {
+		t.Fatalf("Expected %d histogram names, got %d", len(Algorithms), len(HistogramNames))
+	}
+	costs := []float64{10, 10, 10, 12, 15, 15, 20}
+	path := filepath.Join(t.TempDir(), "histogram.png")
+	if err := PlotCostHistogram("Eigen", costs, 4, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error(err)
+	}
+	if err := PlotCostHistogram("Eigen", costs, 0, path); err == nil {
+		t.Error("Expected an error for zero bins")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// ILSOptions are the options for iterated local search
+type ILSOptions struct {
+	// Iterations is the number of perturbations to try
+	Iterations int
+	// Seed seeds the random number generator
+	Seed int64
+	// PerturbType is either "double_bridge" or "random_walk"
+	PerturbType string
+	// Steps is the number of random 2-opt moves of the random walk
+	Steps int
+}
+
+// TwoOpt improves a tour with 2-opt moves until no move improves it, the tour
+// may visit a subset of the cities
+func TwoOpt(dist []float64, size int, tour []int) (float64, []int) {
+	return twoOpt(dist, size, tour, hooks{})
+}
+
+// twoOpt is TwoOpt measuring its regions with the probe of the hooks and
+// explaining its moves with the explainer
+func twoOpt(dist []float64, size int, tour []int, h hooks) (float64, []int) {
+	p, e := h.probe, h.explainer
+	route := append([]int{}, tour...)
+	cost := TourCost(dist, size, route)
+	n := len(route) - 1
+	improved := true
+	for improved {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				move := p.start(ProfileMove)
+				p.reverse(route, i, j)
+				c := p.tourCost(dist, size, route)
+				if c < cost {
+					if e.enabled() {
+						e.explain("reversed positions %d to %d, reducing the cost from %.1f to %.1f", i, j, cost, c)
+					}
+					cost, improved = c, true
+					p.end(ProfileMove, move)
+					continue
+				}
+				p.reverse(route, i, j)
+				p.end(ProfileMove, move)
+			}
+		}
+	}
+	return cost, route
+}
+
+// CitySwap improves a tour by exchanging the positions of two cities until no
+// exchange improves it, the first city stays in place. It is weaker than
+// TwoOpt but a move doesn't reverse a segment of the tour.
+func CitySwap(dist []float64, size int, tour []int) (float64, []int) {
+	route := append([]int{}, tour...)
+	cost := TourCost(dist, size, route)
+	n := len(route) - 1
+	improved := true
+	for improved {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				route[i], route[j] = route[j], route[i]
+				if c := TourCost(dist, size, route); c < cost {
+					cost, improved = c, true
+					continue
+				}
+				route[i], route[j] = route[j], route[i]
+			}
+		}
+	}
+	return cost, route
+}
+
+// OrOptAll improves a tour by moving segments of one to three consecutive
+// cities to another position in the tour until no move improves it, the
+// first city stays in place
+func OrOptAll(dist []float64, size int, tour []int) (float64, []int) {
+	route := append([]int{}, tour...)
+	cost := TourCost(dist, size, route)
+	n := len(route) - 1
+	candidate := make([]int, 0, len(route))
+	improved := true
+	for improved {
+		improved = false
+		for length := 1; length <= 3; length++ {
+			for i := 1; i+length <= n; i++ {
+				segment := route[i : i+length]
+				for p := 1; p <= n-length; p++ {
+					if p == i {
+						continue
+					}
+					// the route without the segment with the segment inserted
+					// before position p of the remaining cities
+					rest := append(append([]int{}, route[:i]...), route[i+length:]...)
+					candidate = append(candidate[:0], rest[:p]...)
+					candidate = append(candidate, segment...)
+					candidate = append(candidate, rest[p:]...)
+					if c := TourCost(dist, size, candidate); c < cost {
+						cost, improved = c, true
+						route = append(route[:0], candidate...)
+						break
+					}
+				}
+			}
+		}
+	}
+	return cost, route
+}
+
+// TourOperator improves a tour, returning its cost and route, such as TwoOpt,
+// OrOptAll and CitySwap
+type TourOperator func(dist []float64, size int, tour []int) (float64, []int)
+
+// Reduce applies the operators in order, starting again from the first
+// operator each time one improves the tour, until none of them does
+func Reduce(dist []float64, size int, tour []int, operators []TourOperator) (float64, []int) {
+	route := append([]int{}, tour...)
+	cost := TourCost(dist, size, route)
+	for i := 0; i < len(operators); i++ {
+		c, r := operators[i](dist, size, route)
+		if c < cost {
+			cost, route = c, r
+			i = -1
+		}
+	}
+	return cost, route
+}
+
+// DoubleBridge cuts the tour into four segments and reconnects them in a
+// different order
+func DoubleBridge(tour []int, rng *rand.Rand) []int {
+	size := len(tour) - 1
+	if size < 4 {
+		return append([]int{}, tour...)
+	}
+	cuts := rng.Perm(size - 1)[:3]
+	for i := range cuts {
+		cuts[i]++
+	}
+	sort.Ints(cuts)
+	a, b, c := cuts[0], cuts[1], cuts[2]
+	route := make([]int, 0, size+1)
+	route = append(route, tour[:a]...)
+	route = append(route, tour[c:size]...)
+	route = append(route, tour[b:c]...)
+	route = append(route, tour[a:b]...)
+	return append(route, route[0])
+}
+
+// RandomWalkPerturb makes steps random 2-opt moves regardless of their cost
+func RandomWalkPerturb(dist []float64, size int, tour []int, steps int, rng *rand.Rand) []int {
+	route := append([]int{}, tour...)
+	if size < 3 {
+		return route
+	}
+	move := func() {
+		i := 1 + rng.Intn(size-1)
+		j := 1 + rng.Intn(size-2)
+		if j >= i {
+			j++
+		} else {
+			i, j = j, i
+		}
+		reverse(route, i, j)
+	}
+	for s := 0; s < steps; s++ {
+		move()
+	}
+	for steps > 0 && equal(route, tour) {
+		move()
+	}
+	return route
+}
+
+// equal returns true if the two routes are the same
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IteratedLocalSearch uses iterated local search to solve the traveling
+// salesman problem
+func IteratedLocalSearch(dist []float64, size int, opts ILSOptions) Tour {
+	return iteratedLocalSearch(dist, size, opts, hooks{})
+}
+
+// iteratedLocalSearch is IteratedLocalSearch passing the hooks on to TwoOpt
+func iteratedLocalSearch(dist []float64, size int, opts ILSOptions, h hooks) Tour {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	route := append(rng.Perm(size), 0)
+	route[size] = route[0]
+	cost, route := twoOpt(dist, size, route, h)
+	for i := 0; i < opts.Iterations; i++ {
+		var perturbed []int
+		switch opts.PerturbType {
+		case "random_walk":
+			perturbed = RandomWalkPerturb(dist, size, route, opts.Steps, rng)
+		case "double_bridge", "":
+			perturbed = DoubleBridge(route, rng)
+		default:
+			panic("unknown perturbation type " + opts.PerturbType)
+		}
+		c, r := twoOpt(dist, size, perturbed, h)
+		if c < cost {
+			cost, route = c, r
+		}
+	}
+	return Tour{
+		Cost:  cost,
+		Route: route,
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandomWalkPerturb(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 8)
+	tour := []int{0, 1, 2, 3, 4, 5, 6, 7, 0}
+	for steps := 1; steps < 8; steps++ {
+		route := RandomWalkPerturb(dist, 8, tour, steps, rng)
+		if err := ValidateTour(route, 8); err != nil {
+			t.Errorf("Invalid tour after %d steps: %v", steps, err)
+		}
+		if equal(route, tour) {
+			t.Errorf("Expected tour to change after %d steps", steps)
+		}
+	}
+}
+
+func TestIteratedLocalSearch(t *testing.T) {
+	optimal, _ := Search(canonical)
+	for _, perturb := range []string{"double_bridge", "random_walk"} {
+		tour := IteratedLocalSearch(canonical, 4, ILSOptions{
+			Iterations:  8,
+			Seed:        1,
+			PerturbType: perturb,
+			Steps:       2,
+		})
+		if err := ValidateTour(tour.Route, 4); err != nil {
+			t.Errorf("Invalid tour for %s: %v", perturb, err)
+		}
+		if tour.Cost != optimal {
+			t.Errorf("Expected cost %f for %s, got %f", optimal, perturb, tour.Cost)
+		}
+	}
+}
+
+func TestCitySwap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	weaker, stronger := 0, 0
+	for i := 0; i < 50; i++ {
+		dist := randomInstance(rng, 10)
+		tour := append(rng.Perm(10), 0)
+		tour[10] = tour[0]
+		swapCost, swapRoute := CitySwap(dist, 10, tour)
+		if err := ValidateTour(swapRoute, 10); err != nil {
+			t.Fatal(err)
+		}
+		if c := TourCost(dist, 10, swapRoute); c != swapCost {
+			t.Errorf("Expected cost %f, got %f", c, swapCost)
+		}
+		if swapCost > TourCost(dist, 10, tour) {
+			t.Errorf("Expected city swap not to make the tour worse, got %f", swapCost)
+		}
+		twoOptCost, _ := TwoOpt(dist, 10, tour)
+		if swapCost > twoOptCost {
+			weaker++
+		} else if swapCost < twoOptCost {
+			stronger++
+		}
+	}
+	if weaker <= stronger {
+		t.Errorf("Expected city swap to be weaker than 2-opt, it was worse on %d and better on %d of 50 instances", weaker, stronger)
+	}
+}
+
+// localSearch benchmarks a local search from a random tour of a 50 city
+// instance
+func localSearch(b *testing.B, search func(dist []float64, size int, tour []int) (float64, []int)) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 50)
+	tour := append(rng.Perm(50), 0)
+	tour[50] = tour[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		search(dist, 50, tour)
+	}
+}
+
+func BenchmarkTwoOpt(b *testing.B) {
+	localSearch(b, TwoOpt)
+}
+
+func BenchmarkCitySwap(b *testing.B) {
+	localSearch(b, CitySwap)
+}
+
+func TestOrOptAll(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		dist := randomInstance(rng, 10)
+		tour := append(rng.Perm(10), 0)
+		tour[10] = tour[0]
+		cost, route := OrOptAll(dist, 10, tour)
+		if err := VerifyTour(dist, 10, Tour{Cost: cost, Route: route}); err != nil {
+			t.Fatal(err)
+		}
+		if cost >= TourCost(dist, 10, tour) {
+			t.Errorf("Expected or-opt to improve a random tour, got %f", cost)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 10)
+	tour := append(rng.Perm(10), 0)
+	tour[10] = tour[0]
+	// costs are the costs of the tours the operators are applied to
+	var costs []float64
+	record := func(operator TourOperator) TourOperator {
+		return func(dist []float64, size int, tour []int) (float64, []int) {
+			costs = append(costs, TourCost(dist, size, tour))
+			return operator(dist, size, tour)
+		}
+	}
+	operators := []TourOperator{record(TwoOpt), record(OrOptAll), record(CitySwap)}
+	cost, route := Reduce(dist, 10, tour, operators)
+	if err := VerifyTour(dist, 10, Tour{Cost: cost, Route: route}); err != nil {
+		t.Fatal(err)
+	}
+	if len(costs) < 4 {
+		t.Errorf("Expected the operators to be applied again after an improvement, got %v", costs)
+	}
+	for i := 1; i < len(costs); i++ {
+		if costs[i] > costs[i-1] {
+			t.Errorf("Expected the cost to decrease monotonically, got %v", costs)
+		}
+	}
+	if cost != costs[len(costs)-1] {
+		t.Errorf("Expected the cost %f of the last tour, got %f", costs[len(costs)-1], cost)
+	}
+	for _, operator := range []TourOperator{TwoOpt, OrOptAll, CitySwap} {
+		if c, _ := operator(dist, 10, route); c < cost {
+			t.Errorf("Expected no operator to improve the reduced tour %f, got %f", cost, c)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// InitRandom starts from a random permutation of the cities
+	InitRandom = "random"
+	// InitNN starts from the nearest neighbor tour from city 0
+	InitNN = "nn"
+	// InitGreedy starts from the greedy edge tour
+	InitGreedy = "greedy"
+	// InitFarthestInsertion starts from the farthest insertion tour
+	InitFarthestInsertion = "farthest-insertion"
+)
+
+// InitialTour builds the initial tour of a local search with the method,
+// which is one of random, nn, greedy or farthest-insertion. An empty method
+// is random.
+func InitialTour(dist []float64, size int, method string, rng *rand.Rand) Tour {
+	var route []int
+	switch method {
+	case InitRandom, "":
+		route = append(rng.Perm(size), 0)
+		route[size] = route[0]
+	case InitNN:
+		return nearestNeighbor(dist, size, 0)
+	case InitGreedy:
+		route = greedyEdgeTour(dist, size)
+	case InitFarthestInsertion:
+		route = farthestInsertionTour(dist, size)
+	default:
+		panic(fmt.Sprintf("unknown initial tour method %q", method))
+	}
+	return Tour{
+		Cost:  TourCost(dist, size, route),
+		Route: route,
+	}
+}
+
+// greedyEdgeTour adds the cheapest edges that keep every city at degree at
+// most 2 without closing a cycle until the edges form a path, which is then
+// closed into a tour from city 0
+func greedyEdgeTour(dist []float64, size int) []int {
+	edges := make([]Edge, 0, size*(size-1)/2)
+	for i := 0; i < size; i++ {
+		for j := i + 1; j < size; j++ {
+			edges = append(edges, Edge{From: i, To: j, Weight: dist[i*size+j]})
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Weight < edges[j].Weight
+	})
+	parent := make([]int, size)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	adj := make([][]int, size)
+	added := 0
+	for _, edge := range edges {
+		if added == size-1 {
+			break
+		}
+		if len(adj[edge.From]) == 2 || len(adj[edge.To]) == 2 {
+			continue
+		}
+		a, b := find(edge.From), find(edge.To)
+		if a == b {
+			continue
+		}
+		parent[a] = b
+		adj[edge.From] = append(adj[edge.From], edge.To)
+		adj[edge.To] = append(adj[edge.To], edge.From)
+		added++
+	}
+	// walk the path from one of its ends
+	start := 0
+	for i := range adj {
+		if len(adj[i]) == 1 {
+			start = i
+			break
+		}
+	}
+	route := make([]int, 0, size+1)
+	previous, city := -1, start
+	for len(route) < size {
+		route = append(route, city)
+		next := -1
+		for _, neighbor := range adj[city] {
+			if neighbor != previous {
+				next = neighbor
+			}
+		}
+		previous, city = city, next
+	}
+	return rotate(append(route, start), 0)
+}
+
+// farthestInsertionTour starts with city 0 and the city farthest from it and
+// repeatedly inserts the city farthest from the tour where it adds the least
+// cost
+func farthestInsertionTour(dist []float64, size int) []int {
+	route := []int{0, 0}
+	inTour := make([]bool, size)
+	inTour[0] = true
+	// closest is the distance from each city to the closest city in the tour
+	closest := make([]float64, size)
+	for i := range closest {
+		closest[i] = dist[i*size]
+	}
+	for len(route) < size+1 {
+		farthest := -1
+		for i := 0; i < size; i++ {
+			if !inTour[i] && (farthest == -1 || closest[i] > closest[farthest]) {
+				farthest = i
+			}
+		}
+		position, min := 1, 0.0
+		for i := 1; i < len(route); i++ {
+			a, b := route[i-1], route[i]
+			added := dist[a*size+farthest] + dist[farthest*size+b] - dist[a*size+b]
+			if i == 1 || added < min {
+				position, min = i, added
+			}
+		}
+		route = append(route[:position], append([]int{farthest}, route[position:]...)...)
+		inTour[farthest] = true
+		for i := 0; i < size; i++ {
+			if d := dist[i*size+farthest]; d < closest[i] {
+				closest[i] = d
+			}
+		}
+	}
+	return route
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestInitialTour(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range []int{1, 2, 3, 8} {
+		dist := euclideanInstance(rng, size)
+		for _, method := range []string{InitRandom, InitNN, InitGreedy, InitFarthestInsertion} {
+			tour := InitialTour(dist, size, method, rng)
+			if err := VerifyTour(dist, size, tour); err != nil {
+				t.Fatalf("%s with %d cities: %v", method, size, err)
+			}
+			if tour.Route[0] != 0 && method != InitRandom {
+				t.Errorf("Expected %s to start at city 0, got %v", method, tour.Route)
+			}
+		}
+	}
+
+	// the greedy edges of cities on a line are between neighbors
+	line := make([]float64, 5*5)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			line[i*5+j] = float64((i - j) * (i - j))
+		}
+	}
+	if route := InitialTour(line, 5, InitGreedy, rng).Route; !equal(route, []int{0, 1, 2, 3, 4, 0}) {
+		t.Errorf("Expected the greedy tour to follow the line, got %v", route)
+	}
+}
+
+// TestSimulatedAnnealingInitMethod compares the median number of iterations to
+// come within 10% of iterated local search. The nearest neighbor start only
+// helps when the starting temperature is low compared to the edge costs of 1
+// to 100, at the default temperature of 50 the first moves randomize either
+// start, so the test anneals from a temperature of 5.
+func TestSimulatedAnnealingInitMethod(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	iterations := map[string][]int{}
+	for i := 0; i < 20; i++ {
+		dist := randomInstance(rng, 12)
+		reference := IteratedLocalSearch(dist, 12, ILSOptions{Iterations: 1000, Seed: 1})
+		for _, method := range []string{InitRandom, InitNN} {
+			for seed := int64(1); seed <= 8; seed++ {
+				tour := SimulatedAnnealing(dist, 12, nil, SAOptions{
+					Iterations:  100000,
+					Temperature: 5,
+					Cooling:     .9999,
+					Target:      1.1 * reference.Cost,
+					Seed:        seed,
+					InitMethod:  method,
+				})
+				iterations[method] = append(iterations[method], tour.Iterations)
+			}
+		}
+	}
+	median := func(values []int) int {
+		sort.Ints(values)
+		return values[len(values)/2]
+	}
+	nn, random := median(iterations[InitNN]), median(iterations[InitRandom])
+	if float64(nn) >= .75*float64(random) {
+		t.Errorf("Expected nn to converge in fewer iterations than random, got medians %d and %d", nn, random)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ReadInstanceFromReader reads an instance in the format, which is one of:
+//
+//	json   an Instance object, the size defaults to the square root of the
+//	       number of distances
+//	csv    the distance matrix with a row per line, optionally with a row
+//	       and a column of city names, and columns of city metadata
+//	tsplib a TSPLIB file with EXPLICIT FULL_MATRIX or EUC_2D edge weights
+//
+// Instances with a negative cycle are rejected.
+func ReadInstanceFromReader(r io.Reader, format string) (*Instance, error) {
+	var instance *Instance
+	var err error
+	switch format {
+	case "json":
+		instance, err = readInstanceJSON(r)
+	case "csv":
+		instance, err = readInstanceCSV(r)
+	case "tsplib":
+		instance, err = readInstanceTSPLIB(r)
+	default:
+		return nil, fmt.Errorf("unknown instance format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if instance.Size < 1 {
+		return nil, fmt.Errorf("instance %s has no cities", instance.Name)
+	}
+	if len(instance.Dist) != instance.Size*instance.Size {
+		return nil, fmt.Errorf("instance %s has %d distances, expected %d",
+			instance.Name, len(instance.Dist), instance.Size*instance.Size)
+	}
+	if HasNegativeCycle(instance.Dist, instance.Size) {
+		return nil, fmt.Errorf("instance %s has a negative cycle", instance.Name)
+	}
+	return instance, nil
+}
+
+// ReadInstanceFile reads an instance from the file, or from stdin if path is
+// "-". If format is empty it is taken from the file extension, .csv, .tsp or
+// json otherwise.
+func ReadInstanceFile(path, format string) (*Instance, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = "csv"
+		case ".tsp":
+			format = "tsplib"
+		default:
+			format = "json"
+		}
+	}
+	if path == "-" {
+		return ReadInstanceFromReader(os.Stdin, format)
+	}
+	input, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+	return ReadInstanceFromReader(input, format)
+}
+
+// readInstanceJSON reads an instance in the json format
+func readInstanceJSON(r io.Reader) (*Instance, error) {
+	var instance Instance
+	err := json.NewDecoder(r).Decode(&instance)
+	if err != nil {
+		return nil, err
+	}
+	if instance.Size == 0 {
+		instance.Size = int(math.Sqrt(float64(len(instance.Dist))))
+	}
+	return &instance, nil
+}
+
+// readInstanceCSV reads an instance in the csv format, the cities are named
+// by the first row or the first column if they aren't numbers. The columns of
+// a named first row past the cities are metadata of the cities.
+func readInstanceCSV(r io.Reader) (*Instance, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	instance := &Instance{}
+	var header []string
+	if len(records) > 0 && !isNumber(records[0][len(records[0])-1]) {
+		header, records = records[0], records[1:]
+	}
+	if len(records) > 0 && !isNumber(records[len(records)-1][0]) {
+		labels := make(CityLabels, len(records))
+		for i, record := range records {
+			labels[i], records[i] = record[0], record[1:]
+		}
+		if header == nil {
+			instance.Labels = labels
+		} else {
+			header = header[1:]
+		}
+	}
+	size := len(records)
+	instance.Size = size
+	instance.Dist = make([]float64, 0, size*size)
+	var keys []string
+	if header != nil {
+		if len(header) < size {
+			return nil, fmt.Errorf("%d labels, expected %d", len(header), size)
+		}
+		instance.Labels, keys = header[:size], header[size:]
+	}
+	if len(keys) > 0 {
+		instance.Metadata = make([]map[string]interface{}, size)
+	}
+	for i, record := range records {
+		if len(record) != size+len(keys) {
+			return nil, fmt.Errorf("row %d has %d distances, expected %d", i, len(record)-len(keys), size)
+		}
+		for _, field := range record[:size] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return nil, err
+			}
+			instance.Dist = append(instance.Dist, value)
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		instance.Metadata[i] = make(map[string]interface{}, len(keys))
+		for j, key := range keys {
+			field := strings.TrimSpace(record[size+j])
+			if value, err := strconv.ParseFloat(field, 64); err == nil {
+				instance.Metadata[i][key] = value
+			} else {
+				instance.Metadata[i][key] = field
+			}
+		}
+	}
+	return instance, nil
+}
+
+// isNumber returns true if the csv field is a number
+func isNumber(field string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+	return err == nil
+}
+
+// readInstanceTSPLIB reads an instance in the tsplib format
+func readInstanceTSPLIB(r io.Reader) (*Instance, error) {
+	instance := &Instance{}
+	weightType, weightFormat, section := "", "", ""
+	var values []float64
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "EOF" {
+			break
+		}
+		if colon := strings.Index(line, ":"); colon >= 0 {
+			key, value := strings.TrimSpace(line[:colon]), strings.TrimSpace(line[colon+1:])
+			switch key {
+			case "NAME":
+				instance.Name = value
+			case "COMMENT":
+				instance.Description = value
+			case "DIMENSION":
+				size, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, err
+				}
+				instance.Size = size
+			case "EDGE_WEIGHT_TYPE":
+				weightType = value
+			case "EDGE_WEIGHT_FORMAT":
+				weightFormat = value
+			}
+			continue
+		}
+		if strings.HasSuffix(line, "_SECTION") {
+			section = line
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	size := instance.Size
+	switch {
+	case weightType == "EXPLICIT" && weightFormat == "FULL_MATRIX" && section == "EDGE_WEIGHT_SECTION":
+		instance.Dist = values
+	case weightType == "EUC_2D" && section == "NODE_COORD_SECTION":
+		if len(values) != 3*size {
+			return nil, fmt.Errorf("%d coordinate values, expected %d", len(values), 3*size)
+		}
+		instance.Dist = make([]float64, size*size)
+		for i := 0; i < size; i++ {
+			for j := 0; j < size; j++ {
+				x := values[3*i+1] - values[3*j+1]
+				y := values[3*i+2] - values[3*j+2]
+				instance.Dist[i*size+j] = math.Round(math.Sqrt(x*x + y*y))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tsplib edge weights %s %s", weightType, weightFormat)
+	}
+	return instance, nil
+}
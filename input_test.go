@@ -0,0 +1,141 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReadInstanceFromReader(t *testing.T) {
+	input := bytes.NewBufferString(`{"name": "line4", "dist": [
+		0, 1, 2, 3,
+		1, 0, 1, 2,
+		2, 1, 0, 1,
+		3, 2, 1, 0
+	]}`)
+	instance, err := ReadInstanceFromReader(input, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instance.Name != "line4" || instance.Size != 4 {
+		t.Errorf("Expected line4 with 4 cities, got %s with %d", instance.Name, instance.Size)
+	}
+	results := CompareSolvers(SizedSolvers(DefaultSolvers(), instance.Size), instance.Dist, instance.Size)
+	if results[0].Tour.Cost != 6 {
+		t.Errorf("Expected the best tour to cost 6, got %f", results[0].Tour.Cost)
+	}
+
+	csv, err := ReadInstanceFromReader(bytes.NewBufferString("0, 1, 2, 3\n1, 0, 1, 2\n2, 1, 0, 1\n3, 2, 1, 0\n"), "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(csv.Dist, instance.Dist) {
+		t.Errorf("Expected %v, got %v", instance.Dist, csv.Dist)
+	}
+
+	tsplib, err := ReadInstanceFromReader(bytes.NewBufferString(`NAME: square
+TYPE: TSP
+DIMENSION: 4
+EDGE_WEIGHT_TYPE: EUC_2D
+NODE_COORD_SECTION
+1 0 0
+2 3 0
+3 3 4
+4 0 4
+EOF
+`), "tsplib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{
+		0, 3, 5, 4,
+		3, 0, 4, 5,
+		5, 4, 0, 3,
+		4, 5, 3, 0,
+	}
+	if tsplib.Name != "square" || !reflect.DeepEqual(tsplib.Dist, expected) {
+		t.Errorf("Expected square %v, got %s %v", expected, tsplib.Name, tsplib.Dist)
+	}
+
+	if _, err := ReadInstanceFromReader(bytes.NewBufferString("0, 1\n1\n"), "csv"); err == nil {
+		t.Error("Expected an error for a ragged matrix")
+	}
+	if _, err := ReadInstanceFromReader(bytes.NewBufferString(`{"size": 3, "dist": [0, 1]}`), "json"); err == nil {
+		t.Error("Expected an error for the wrong number of distances")
+	}
+	if _, err := ReadInstanceFromReader(bytes.NewBufferString(""), "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestSizedSolvers(t *testing.T) {
+	solvers := SizedSolvers(DefaultSolvers(), 6)
+	for _, name := range []string{"eigen", "nearestneighbor"} {
+		if _, ok := solvers[name]; ok {
+			t.Errorf("Expected %s to require Size cities", name)
+		}
+	}
+	if _, ok := solvers["tabu"]; !ok {
+		t.Error("Expected tabu to work with any number of cities")
+	}
+	if len(SizedSolvers(DefaultSolvers(), Size)) != len(DefaultSolvers()) {
+		t.Error("Expected all of the solvers to work with Size cities")
+	}
+}
+
+func TestReadInstanceCSVLabels(t *testing.T) {
+	matrix := []float64{
+		0, 1, 2,
+		1, 0, 3,
+		2, 3, 0,
+	}
+	labels := CityLabels{"A", "B", "C"}
+	for _, input := range []string{
+		"A, B, C\n0, 1, 2\n1, 0, 3\n2, 3, 0\n",
+		"A, 0, 1, 2\nB, 1, 0, 3\nC, 2, 3, 0\n",
+		", A, B, C\nA, 0, 1, 2\nB, 1, 0, 3\nC, 2, 3, 0\n",
+	} {
+		instance, err := ReadInstanceFromReader(bytes.NewBufferString(input), "csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(instance.Labels, labels) || !reflect.DeepEqual(instance.Dist, matrix) {
+			t.Errorf("Expected %v %v, got %v %v for %q", labels, matrix, instance.Labels, instance.Dist, input)
+		}
+	}
+	if _, err := ReadInstanceFromReader(bytes.NewBufferString("A, B\n0, 1, 2\n1, 0, 3\n2, 3, 0\n"), "csv"); err == nil {
+		t.Error("Expected an error for the wrong number of labels")
+	}
+}
+
+func TestReadInstanceCSVMetadata(t *testing.T) {
+	input := ", A, B, C, population, region\nA, 0, 1, 2, 100, north\nB, 1, 0, 3, 2500, south\nC, 2, 3, 0, 40, north\n"
+	instance, err := ReadInstanceFromReader(bytes.NewBufferString(input), "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]interface{}{
+		{"population": 100.0, "region": "north"},
+		{"population": 2500.0, "region": "south"},
+		{"population": 40.0, "region": "north"},
+	}
+	if instance.Size != 3 || !reflect.DeepEqual(instance.Metadata, expected) {
+		t.Fatalf("Expected 3 cities with %v, got %d with %v", expected, instance.Size, instance.Metadata)
+	}
+	encoded, err := json.Marshal(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ReadInstanceFromReader(bytes.NewBuffer(encoded), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, instance) {
+		t.Errorf("Expected %v, got %v", instance, decoded)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// NearestInsertionTrace builds a tour by nearest insertion, starting with city
+// 0 and repeatedly inserting the city closest to the tour where it adds the
+// least cost. The trace is the cost added by each insertion, one entry for
+// each city after city 0, so it sums to the cost of the tour.
+func NearestInsertionTrace(dist []float64, size int) (Tour, []float64) {
+	route := []int{0, 0}
+	inTour := make([]bool, size)
+	inTour[0] = true
+	// closest is the distance from each city to the closest city in the tour
+	closest := make([]float64, size)
+	for i := range closest {
+		closest[i] = dist[i*size]
+	}
+	trace := make([]float64, 0, size-1)
+	cost := TourCost(dist, size, route)
+	for len(route) < size+1 {
+		nearest := -1
+		for i := 0; i < size; i++ {
+			if !inTour[i] && (nearest == -1 || closest[i] < closest[nearest]) {
+				nearest = i
+			}
+		}
+		position, min := 1, 0.0
+		for i := 1; i < len(route); i++ {
+			a, b := route[i-1], route[i]
+			added := dist[a*size+nearest] + dist[nearest*size+b] - dist[a*size+b]
+			if i == 1 || added < min {
+				position, min = i, added
+			}
+		}
+		route = append(route[:position], append([]int{nearest}, route[position:]...)...)
+		inTour[nearest] = true
+		trace = append(trace, min)
+		cost += min
+		for i := 0; i < size; i++ {
+			if d := dist[i*size+nearest]; d < closest[i] {
+				closest[i] = d
+			}
+		}
+	}
+	return Tour{Cost: cost, Route: route}, trace
+}
+
+// PlotInsertionTrace plots the cost added by each insertion of a trace from
+// NearestInsertionTrace
+func PlotInsertionTrace(trace []float64, path string) error {
+	points := make(plotter.XYs, len(trace))
+	for i, added := range trace {
+		points[i].X = float64(i + 1)
+		points[i].Y = added
+	}
+
+	p := plot.New()
+
+	p.Title.Text = "nearest insertion"
+	p.X.Label.Text = "insertion"
+	p.Y.Label.Text = "added cost"
+
+	line, scatter, err := plotter.NewLinePoints(points)
+	if err != nil {
+		return err
+	}
+	p.Add(line, scatter)
+
+	return p.Save(8*vg.Inch, 8*vg.Inch, path)
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNearestInsertionTrace(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		dist := randomInstance(rng, 10)
+		tour, trace := NearestInsertionTrace(dist, 10)
+		if err := VerifyTour(dist, 10, tour); err != nil {
+			t.Fatal(err)
+		}
+		if len(trace) != 9 {
+			t.Fatalf("Expected an insertion for each of 9 cities, got %d", len(trace))
+		}
+		sum := 0.0
+		for _, added := range trace {
+			sum += added
+		}
+		if math.Abs(sum-tour.Cost) > 1e-9 {
+			t.Errorf("Expected the insertions to sum to the cost %f, got %f", tour.Cost, sum)
+		}
+	}
+	_, trace := NearestInsertionTrace(canonical, Size)
+	path := filepath.Join(t.TempDir(), "insertion.png")
+	if err := PlotInsertionTrace(trace, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Instance is an instance of the traveling salesman problem
+type Instance struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Size        int       `json:"size"`
+	Dist        []float64 `json:"dist"`
+	// Optimal is the known optimal cost, zero if unknown
+	Optimal float64 `json:"optimal,omitempty"`
+	// Labels are the names of the cities, nil if the cities are unnamed
+	Labels CityLabels `json:"labels,omitempty"`
+	// Metadata are the attributes of each city, such as its population or
+	// region, nil if there are none
+	Metadata []map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// CityLabels are the names of the cities by index
+type CityLabels []string
+
+// Label returns the name of the city, or its index if it has no name
+func (l CityLabels) Label(city int) string {
+	if city < 0 || city >= len(l) {
+		return strconv.Itoa(city)
+	}
+	return l[city]
+}
+
+// Route names the cities of the route joined by arrows
+func (l CityLabels) Route(route []int) string {
+	names := make([]string, len(route))
+	for i, city := range route {
+		names[i] = l.Label(city)
+	}
+	return strings.Join(names, " → ")
+}
+
+// NewDenseMatrix flattens the rows of a square distance matrix with a zero
+// diagonal, returning the distances and the number of cities
+func NewDenseMatrix(rows [][]float64) ([]float64, int, error) {
+	size := len(rows)
+	dist := make([]float64, 0, size*size)
+	for i, row := range rows {
+		if len(row) != size {
+			return nil, 0, fmt.Errorf("row %d has %d distances, expected %d", i, len(row), size)
+		}
+		if row[i] != 0 {
+			return nil, 0, fmt.Errorf("distance from city %d to itself is %f, expected 0", i, row[i])
+		}
+		dist = append(dist, row...)
+	}
+	return dist, size, nil
+}
+
+// MustNewDenseMatrix is NewDenseMatrix for tests and examples, it panics if
+// the matrix is invalid
+func MustNewDenseMatrix(rows [][]float64) ([]float64, int) {
+	dist, size, err := NewDenseMatrix(rows)
+	if err != nil {
+		panic(err)
+	}
+	return dist, size
+}
+
+// Normalize returns a copy of the instance with the distances divided by the
+// largest off-diagonal distance, so they are in [0, 1] and tour costs are
+// scaled by the same factor
+func (inst *Instance) Normalize() *Instance {
+	max := 0.0
+	for i := 0; i < inst.Size; i++ {
+		for j := 0; j < inst.Size; j++ {
+			if i != j && inst.Dist[i*inst.Size+j] > max {
+				max = inst.Dist[i*inst.Size+j]
+			}
+		}
+	}
+	normalized := *inst
+	normalized.Dist = append([]float64{}, inst.Dist...)
+	if max == 0 {
+		return &normalized
+	}
+	for i := range normalized.Dist {
+		normalized.Dist[i] /= max
+	}
+	normalized.Optimal /= max
+	return &normalized
+}
+
+// Perturb returns a noisy copy of the distances for robustness testing, each
+// distance is multiplied by 1 + noiseFraction*rng.NormFloat64() and clamped
+// to be at least zero, and the diagonal is zero. Symmetric distances stay
+// symmetric, both directions between two cities get the same noise.
+func Perturb(dist []float64, size int, noiseFraction float64, rng *rand.Rand) []float64 {
+	symmetric := SymmetryCheck(dist, size)
+	perturbed := make([]float64, len(dist))
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i == j || symmetric && j < i {
+				continue
+			}
+			d := dist[i*size+j] * (1 + noiseFraction*rng.NormFloat64())
+			if d < 0 {
+				d = 0
+			}
+			perturbed[i*size+j] = d
+			if symmetric {
+				perturbed[j*size+i] = d
+			}
+		}
+	}
+	return perturbed
+}
+
+// Perturb returns a copy of the instance with the distances perturbed by
+// Perturb. The optimal cost of the copy isn't known.
+func (inst *Instance) Perturb(noiseFraction float64, rng *rand.Rand) *Instance {
+	perturbed := *inst
+	perturbed.Dist = Perturb(inst.Dist, inst.Size, noiseFraction, rng)
+	perturbed.Optimal = 0
+	return &perturbed
+}
+
+//go:embed testdata/instances.json
+var testInstances []byte
+
+// LoadTestInstances loads the standard set of instances with known optimal
+// costs
+func LoadTestInstances() ([]Instance, error) {
+	var instances []Instance
+	err := json.Unmarshal(testInstances, &instances)
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		if len(instance.Dist) != instance.Size*instance.Size {
+			return nil, fmt.Errorf("instance %s has %d distances, expected %d",
+				instance.Name, len(instance.Dist), instance.Size*instance.Size)
+		}
+	}
+	return instances, nil
+}
+
+// MustTestInstance returns the named standard instance and panics if it
+// doesn't exist
+func MustTestInstance(name string) Instance {
+	instances, err := LoadTestInstances()
+	if err != nil {
+		panic(err)
+	}
+	for _, instance := range instances {
+		if instance.Name == name {
+			return instance
+		}
+	}
+	panic(fmt.Sprintf("test instance %s not found", name))
+}
+
+//go:embed testdata/benchmarks.json
+var benchmarkInstances []byte
+
+// BenchmarkInstance returns the named benchmark instance with its optimal cost
+// for reproducible experiments: trivial4, symmetric8 or random12
+func BenchmarkInstance(name string) (*Instance, error) {
+	var instances []Instance
+	err := json.Unmarshal(benchmarkInstances, &instances)
+	if err != nil {
+		return nil, err
+	}
+	for i := range instances {
+		if instances[i].Name == name {
+			return &instances[i], nil
+		}
+	}
+	return nil, fmt.Errorf("benchmark instance %s not found", name)
+}
@@ -0,0 +1,206 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestKnownOptimal(t *testing.T) {
+	instances, err := LoadTestInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 10 {
+		t.Fatalf("Expected 10 instances, got %d", len(instances))
+	}
+	// gap is the documented worst ratio to optimal of each heuristic on
+	// the standard instances, symmetric heuristics skip asymmetric instances
+	heuristics := []struct {
+		Name      string
+		Gap       float64
+		Symmetric bool
+		Solver    Solver
+	}{
+		{"tabu", 1, false, SolverFunc(func(dist []float64, size int) Tour {
+			return TabuSearch(dist, size, TabuOptions{Iterations: 100 * size, Tenure: size / 2, Seed: 1})
+		})},
+		{"ils", 1, false, SolverFunc(func(dist []float64, size int) Tour {
+			return IteratedLocalSearch(dist, size, ILSOptions{Iterations: 10 * size, Seed: 1})
+		})},
+		{"beam", 1.5, false, SolverFunc(func(dist []float64, size int) Tour {
+			return BeamSearch(dist, size, size)
+		})},
+		{"savings", 2, true, SolverFunc(func(dist []float64, size int) Tour {
+			return SavingsAlgorithm(dist, size, 0)
+		})},
+		{"christofides", 2, true, SolverFunc(Christofides)},
+	}
+	for _, instance := range instances {
+		oracle := NewOracle(instance.Dist, instance.Size)
+		cost, route := oracle.Optimal().Cost, oracle.Optimal().Route
+		if cost != instance.Optimal {
+			t.Errorf("Expected Search to find %f for %s, got %f", instance.Optimal, instance.Name, cost)
+		}
+		if err := ValidateTour(route, instance.Size); err != nil {
+			t.Errorf("Invalid Search tour for %s: %v", instance.Name, err)
+		}
+		symmetric := true
+		for i := 0; i < instance.Size; i++ {
+			for j := 0; j < instance.Size; j++ {
+				if instance.Dist[i*instance.Size+j] != instance.Dist[j*instance.Size+i] {
+					symmetric = false
+				}
+			}
+		}
+		for _, heuristic := range heuristics {
+			if heuristic.Symmetric && !symmetric {
+				continue
+			}
+			tour := heuristic.Solver.Solve(instance.Dist, instance.Size)
+			if err := ValidateTour(tour.Route, instance.Size); err != nil {
+				t.Errorf("Invalid %s tour for %s: %v", heuristic.Name, instance.Name, err)
+			}
+			if err := oracle.Verify(tour); err != nil {
+				t.Errorf("Expected %s to not undercut Search for %s: %v", heuristic.Name, instance.Name, err)
+			}
+			if tour.Cost > heuristic.Gap*instance.Optimal {
+				t.Errorf("Expected %s cost at most %f for %s, got %f",
+					heuristic.Name, heuristic.Gap*instance.Optimal, instance.Name, tour.Cost)
+			}
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	instances, err := LoadTestInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, instance := range instances {
+		normalized := instance.Normalize()
+		max := 0.0
+		for _, d := range normalized.Dist {
+			max = math.Max(max, d)
+		}
+		if max != 1 {
+			t.Errorf("Expected the largest distance of %s to be 1, got %f", instance.Name, max)
+		}
+		optimal, route := Search(normalized.Dist)
+		if math.Abs(optimal-normalized.Optimal) > 1e-9 {
+			t.Errorf("Expected the optimal cost of %s to be %f, got %f", instance.Name, normalized.Optimal, optimal)
+		}
+		ratio := TourCost(instance.Dist, instance.Size, route) / optimal
+		for _, other := range [][]int{route, nearestNeighbor(instance.Dist, instance.Size, 0).Route} {
+			if r := TourCost(instance.Dist, instance.Size, other) / TourCost(normalized.Dist, normalized.Size, other); math.Abs(r-ratio) > 1e-9 {
+				t.Errorf("Expected the costs of %s to scale by %f, got %f", instance.Name, ratio, r)
+			}
+		}
+	}
+	if instances[0].Dist[1] == 1 {
+		t.Error("Expected Normalize to copy the distances")
+	}
+}
+
+func TestPerturb(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	instances, err := LoadTestInstances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, instance := range instances {
+		for _, noise := range []float64{0, .1, .5} {
+			perturbed := instance.Perturb(noise, rng)
+			if perturbed.Size != instance.Size || len(perturbed.Dist) != len(instance.Dist) {
+				t.Fatalf("Expected %s to keep its size, got %d", instance.Name, perturbed.Size)
+			}
+			if perturbed.Optimal != 0 {
+				t.Errorf("Expected the optimal cost of the perturbed %s to be unknown", instance.Name)
+			}
+			// the noise is normal, with about a thousand draws over the
+			// instances a distance can be more than 3 standard deviations off
+			max := 0.0
+			for _, d := range instance.Dist {
+				max = math.Max(max, d)
+			}
+			for i := 0; i < instance.Size; i++ {
+				for j := 0; j < instance.Size; j++ {
+					d := perturbed.Dist[i*instance.Size+j]
+					if i == j && d != 0 {
+						t.Errorf("Expected a zero diagonal for %s, got %f", instance.Name, d)
+					}
+					if d < 0 || math.Abs(d-instance.Dist[i*instance.Size+j]) > noise*5*max {
+						t.Errorf("Expected %s distance %d,%d near %f with noise %f, got %f",
+							instance.Name, i, j, instance.Dist[i*instance.Size+j], noise, d)
+					}
+				}
+			}
+			if SymmetryCheck(instance.Dist, instance.Size) && !SymmetryCheck(perturbed.Dist, perturbed.Size) {
+				t.Errorf("Expected the perturbed %s to stay symmetric", instance.Name)
+			}
+		}
+	}
+
+	// asymmetric distances get noise in each direction
+	asymmetric, size := MustNewDenseMatrix([][]float64{
+		{0, 1, 2},
+		{2, 0, 1},
+		{1, 2, 0},
+	})
+	if SymmetryCheck(Perturb(asymmetric, size, .1, rng), size) {
+		t.Error("Expected perturbed asymmetric distances to stay asymmetric")
+	}
+}
+
+func TestNewDenseMatrix(t *testing.T) {
+	dist, size := MustNewDenseMatrix([][]float64{
+		{0, 20, 42, 35},
+		{20, 0, 30, 34},
+		{42, 30, 0, 12},
+		{35, 34, 12, 0},
+	})
+	if size != Size || !reflect.DeepEqual(dist, canonical) {
+		t.Errorf("Expected %d cities %v, got %d %v", Size, canonical, size, dist)
+	}
+	if _, _, err := NewDenseMatrix([][]float64{{0, 1}, {1}}); err == nil {
+		t.Error("Expected an error for a matrix that isn't square")
+	}
+	if _, _, err := NewDenseMatrix([][]float64{{0, 1}, {1, 1}}); err == nil {
+		t.Error("Expected an error for a nonzero diagonal")
+	}
+}
+
+func TestBenchmarkInstance(t *testing.T) {
+	for _, name := range []string{"trivial4", "symmetric8", "random12"} {
+		instance, err := BenchmarkInstance(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(instance.Dist) != instance.Size*instance.Size || !SymmetryCheck(instance.Dist, instance.Size) {
+			t.Fatalf("Expected %s to be a symmetric %d city instance", name, instance.Size)
+		}
+		if bound := AssignmentLowerBound(instance.Dist, instance.Size); bound > instance.Optimal {
+			t.Errorf("Expected the optimal cost of %s to be at least the bound %f, got %f", name, bound, instance.Optimal)
+		}
+		tour := TabuSearch(instance.Dist, instance.Size, defaultTabuOptions(instance.Size, 1))
+		if tour.Cost < instance.Optimal {
+			t.Errorf("Expected the optimal cost of %s to be at most %f, got %f", name, tour.Cost, instance.Optimal)
+		}
+		if instance.Size <= 8 {
+			if cost, _ := Search(instance.Dist); cost != instance.Optimal {
+				t.Errorf("Expected the optimal cost of %s to be %f, got %f", name, cost, instance.Optimal)
+			}
+		}
+	}
+	if trivial, _ := BenchmarkInstance("trivial4"); !reflect.DeepEqual(trivial.Dist, canonical) {
+		t.Errorf("Expected trivial4 to be the canonical instance, got %v", trivial.Dist)
+	}
+	if _, err := BenchmarkInstance("att48"); err == nil {
+		t.Error("Expected an error for a missing instance")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hungarian solves the assignment problem
+package hungarian
+
+import (
+	"math"
+)
+
+// Hungarian solves the n by n assignment problem for the row major cost
+// matrix, returning the column assigned to each row and the total cost. The
+// row and column potentials perform the reductions and each row is added with
+// a shortest augmenting path, giving O(n^3) time.
+func Hungarian(cost []float64, n int) ([]int, float64) {
+	// u and v are the row and column potentials, p maps a column to its row
+	u, v := make([]float64, n+1), make([]float64, n+1)
+	p, way := make([]int, n+1), make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, n+1)
+		for j := range minv {
+			minv[j] = math.MaxFloat64
+		}
+		used := make([]bool, n+1)
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.MaxFloat64, 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				cur := cost[(i0-1)*n+j-1] - u[i0] - v[j]
+				if cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assignment, total := make([]int, n), 0.0
+	for j := 1; j <= n; j++ {
+		if p[j] != 0 {
+			assignment[p[j]-1] = j - 1
+		}
+	}
+	for i, j := range assignment {
+		total += cost[i*n+j]
+	}
+	return assignment, total
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hungarian
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHungarian3(t *testing.T) {
+	cost := []float64{
+		4, 1, 3,
+		2, 0, 5,
+		3, 2, 2,
+	}
+	assignment, total := Hungarian(cost, 3)
+	if total != 5 {
+		t.Errorf("Expected cost of 5, got %f", total)
+	}
+	expected := []int{1, 0, 2}
+	for i, j := range assignment {
+		if j != expected[i] {
+			t.Errorf("Expected assignment %v, got %v", expected, assignment)
+			break
+		}
+	}
+}
+
+func TestHungarian4(t *testing.T) {
+	cost := []float64{
+		9, 2, 7, 8,
+		6, 4, 3, 7,
+		5, 8, 1, 8,
+		7, 6, 9, 4,
+	}
+	assignment, total := Hungarian(cost, 4)
+	if total != 13 {
+		t.Errorf("Expected cost of 13, got %f", total)
+	}
+	expected := []int{1, 0, 2, 3}
+	for i, j := range assignment {
+		if j != expected[i] {
+			t.Errorf("Expected assignment %v, got %v", expected, assignment)
+			break
+		}
+	}
+}
+
+func TestHungarianBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var permute func(i int, columns []int, cost []float64, n int) float64
+	permute = func(i int, columns []int, cost []float64, n int) float64 {
+		if i == n {
+			total := 0.0
+			for r, c := range columns {
+				total += cost[r*n+c]
+			}
+			return total
+		}
+		min := -1.0
+		for j := i; j < n; j++ {
+			columns[i], columns[j] = columns[j], columns[i]
+			if total := permute(i+1, columns, cost, n); min < 0 || total < min {
+				min = total
+			}
+			columns[i], columns[j] = columns[j], columns[i]
+		}
+		return min
+	}
+	for n := 1; n <= 6; n++ {
+		cost := make([]float64, n*n)
+		for i := range cost {
+			cost[i] = float64(rng.Intn(100))
+		}
+		columns := make([]int, n)
+		for i := range columns {
+			columns[i] = i
+		}
+		expected := permute(0, columns, cost, n)
+		if _, total := Hungarian(cost, n); total != expected {
+			t.Errorf("Expected cost of %f for n=%d, got %f", expected, n, total)
+		}
+	}
+}
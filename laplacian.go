@@ -0,0 +1,122 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// laplacianVectors are the eigenvectors of the normalized graph Laplacian
+// L = D^-1/2 (D - A) D^-1/2 in ascending order of eigenvalue, where A is the
+// symmetric graph with a link between i and j weighted by the inverse of the
+// cost between them
+func laplacianVectors(dist []float64, size int) *mat.Dense {
+	weights := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i == j {
+				continue
+			}
+			if d := dist[i*size+j] + dist[j*size+i]; d > 0 {
+				weights[i*size+j] = 2 / d
+			}
+		}
+	}
+	degrees := make([]float64, size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			degrees[i] += weights[i*size+j]
+		}
+	}
+	laplacian := mat.NewSymDense(size, nil)
+	for i := 0; i < size; i++ {
+		for j := i; j < size; j++ {
+			if degrees[i] == 0 || degrees[j] == 0 {
+				continue
+			}
+			value := -weights[i*size+j] / math.Sqrt(degrees[i]*degrees[j])
+			if i == j {
+				value = 1
+			}
+			laplacian.SetSym(i, j, value)
+		}
+	}
+
+	var eig mat.EigenSym
+	ok := eig.Factorize(laplacian, true)
+	if !ok {
+		panic("Eigendecomposition failed")
+	}
+	var vectors mat.Dense
+	eig.VectorsTo(&vectors)
+	return &vectors
+}
+
+// LaplacianEigen embeds the cities with the eigenvectors of the normalized
+// graph Laplacian, see laplacianVectors. The eigenvector of the smallest
+// eigenvalue is skipped, so the first coordinate is the Fiedler vector. Like
+// Eigen the embedding distances are scaled by the costs and nearest neighbor
+// is run from every city.
+func LaplacianEigen(dist []float64, size int) Tour {
+	vectors := laplacianVectors(dist, size)
+	distances := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i == j {
+				continue
+			}
+			sum := 0.0
+			for k := 1; k < size; k++ {
+				x := vectors.At(i, k) - vectors.At(j, k)
+				sum += x * x
+			}
+			distances[i*size+j] = math.Sqrt(sum) * dist[i*size+j]
+		}
+	}
+	best := Tour{Cost: math.MaxFloat64}
+	for offset := 0; offset < size; offset++ {
+		route := nearestNeighbor(distances, size, offset).Route
+		if cost := TourCost(dist, size, route); cost < best.Cost {
+			best.Cost, best.Route = cost, route
+		}
+	}
+	return best
+}
+
+// HybridEigenNN orders the cities along the Fiedler vector of the normalized
+// graph Laplacian, see laplacianVectors, and runs nearest neighbor on the
+// costs from every city. The costs aren't changed, the order only breaks ties
+// between equally close cities in favor of the city earliest in the order.
+func HybridEigenNN(dist []float64, size int) Tour {
+	vectors := laplacianVectors(dist, size)
+	order := make([]int, size)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return vectors.At(order[i], 1) < vectors.At(order[j], 1)
+	})
+	// ordered are the costs between the cities by position in the order
+	ordered := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			ordered[i*size+j] = dist[order[i]*size+order[j]]
+		}
+	}
+	best := Tour{Cost: math.MaxFloat64}
+	for position := 0; position < size; position++ {
+		route := nearestNeighbor(ordered, size, position).Route
+		for i, p := range route {
+			route[i] = order[p]
+		}
+		if cost := TourCost(dist, size, route); cost < best.Cost {
+			best.Cost, best.Route = cost, route
+		}
+	}
+	return best
+}
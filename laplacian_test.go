@@ -0,0 +1,60 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLaplacianEigen(t *testing.T) {
+	// cities on a line in a shuffled order, the optimal tour goes to the end
+	// of the line and back
+	size := 6
+	position := rand.New(rand.NewSource(1)).Perm(size)
+	dist := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			d := position[i] - position[j]
+			if d < 0 {
+				d = -d
+			}
+			dist[i*size+j] = float64(d)
+		}
+	}
+	tour := LaplacianEigen(dist, size)
+	if err := ValidateTour(tour.Route, size); err != nil {
+		t.Fatal(err)
+	}
+	if c := TourCost(dist, size, tour.Route); c != tour.Cost {
+		t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+	}
+	if expected := float64(2 * (size - 1)); tour.Cost != expected {
+		t.Errorf("Expected cost %f, got %f", expected, tour.Cost)
+	}
+}
+
+func TestHybridEigenNN(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 16; i++ {
+		dist := euclideanInstance(rng, 8)
+		tour := HybridEigenNN(dist, 8)
+		if err := ValidateTour(tour.Route, 8); err != nil {
+			t.Fatal(err)
+		}
+		if c := TourCost(dist, 8, tour.Route); c != tour.Cost {
+			t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+		}
+		// without ties the order doesn't change the nearest neighbor tours
+		best := math.MaxFloat64
+		for start := 0; start < 8; start++ {
+			best = math.Min(best, nearestNeighbor(dist, 8, start).Cost)
+		}
+		if tour.Cost != best {
+			t.Errorf("Expected the best nearest neighbor cost %f, got %f", best, tour.Cost)
+		}
+	}
+}
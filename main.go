@@ -5,18 +5,28 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"math"
 	"math/cmplx"
 	"math/rand"
 	"os"
+	"runtime"
+	"runtime/pprof"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 	"gonum.org/v1/gonum/stat"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 
@@ -34,53 +44,349 @@ const (
 var (
 	// FlagDebug debug mode
 	FlagDebug = flag.Bool("debug", false, "debug mode")
+	// FlagCompare compare mode
+	FlagCompare = flag.Bool("compare", false, "compare the solvers on the debug instance")
+	// FlagProfile cpu profile output file
+	FlagProfile = flag.String("profile", "", "write a cpu profile of the benchmark to the file")
+	// FlagMemProfile heap profile output file
+	FlagMemProfile = flag.String("memprofile", "", "write a heap profile after the benchmark to the file")
+	// FlagParallel number of goroutines to run the trials on
+	FlagParallel = flag.Int("parallel", 1, "number of goroutines to run the benchmark trials on")
+	// FlagReduction the dimensionality reduction of the debug plot
+	FlagReduction = flag.String("reduction", "pca", "dimensionality reduction of the debug plot: pca or tsne")
+	// FlagPlotParallel parallel coordinate plot of the benchmark
+	FlagPlotParallel = flag.Bool("plot-parallel", false, "plot the cost of each algorithm per trial as parallel coordinates")
+	// FlagInput instance file to solve
+	FlagInput = flag.String("input", "", "compare the solvers on the instance in the file, - reads from stdin")
+	// FlagWatch resolve the instance when the file changes
+	FlagWatch = flag.Bool("watch", false, "compare the solvers on the -input instance again each time the file changes")
+	// FlagFormat format of the instance file
+	FlagFormat = flag.String("format", "", "format of the input instance: json, csv or tsplib, defaults to the file extension")
+	// FlagSeedFromInstance derive the solver seeds from the instance
+	FlagSeedFromInstance = flag.Bool("seed-from-instance", false, "derive the seed of each solver from the instance and the solver name")
+	// FlagSymmetrize symmetrize asymmetric inputs of the symmetric solvers
+	FlagSymmetrize = flag.String("symmetrize", "", "symmetrize asymmetric inputs of the solvers that require symmetric distances: min, max or avg")
+	// FlagTSPLIB instance files to benchmark
+	FlagTSPLIB = flag.String("tsplib", "", "comma separated instance files to benchmark the solvers on, the results are written to benchmark_results.csv")
+	// FlagTimeLimit time limit of each solver in the tsplib benchmark
+	FlagTimeLimit = flag.Duration("time-limit", time.Minute, "time limit of each solver on each instance of the tsplib benchmark")
+	// FlagListMethods list the solvers
+	FlagListMethods = flag.Bool("list-methods", false, "print the solvers with their complexity, approximation guarantee and warm start support")
+	// FlagResume checkpoint to resume
+	FlagResume = flag.String("resume", "", "resume the simulated annealing run of the checkpoint file on the instance")
+	// FlagVerify tour file to verify
+	FlagVerify = flag.String("verify", "", "verify the validity and the cost of the json tour in the file against the instance")
+	// FlagDryRun print the solver configuration without solving
+	FlagDryRun = flag.Bool("dry-run", false, "print the instance size and the solver configurations and estimated runtimes without solving")
+	// FlagQuiet only write the final result as json
+	FlagQuiet = flag.Bool("quiet", false, "discard all output except the final result, written as a line of json")
+	// FlagOutput format of the best tour
+	FlagOutput = flag.String("output", "", "write only the best tour in the format: text, json or csv")
+	// FlagEigenStrategy eigenvalue selection strategy of Eigen
+	FlagEigenStrategy = flag.String("eigen-strategy", EigenAll, "eigenvalues used by eigen: all, top-k, bottom-k or real-only")
+	// FlagEigenK number of eigenvalues selected by top-k and bottom-k
+	FlagEigenK = flag.Int("eigen-k", Size, "number of eigenvalues used by the top-k and bottom-k strategies")
+	// FlagEigenComponent component of the scaled eigenvectors used by Eigen
+	FlagEigenComponent = flag.String("eigen-component", ComponentReal, "component of the scaled eigenvectors used by eigen: real, imag or complex")
+	// FlagEigenNormalize L2 normalize the eigenvectors used by Eigen
+	FlagEigenNormalize = flag.Bool("eigen-normalize", false, "L2 normalize the eigenvectors used by eigen")
+	// FlagEigenCombine combination of the right and left eigenvectors used by Eigen
+	FlagEigenCombine = flag.String("eigen-combine", CombineBest, "combination of the right and left eigenvectors used by eigen: best, average or concat")
 )
 
+// flagEigenOptions returns the Eigen options set by the flags
+func flagEigenOptions() EigenOptions {
+	return EigenOptions{
+		SelectionStrategy: *FlagEigenStrategy,
+		K:                 *FlagEigenK,
+		Component:         *FlagEigenComponent,
+		NormalizeVectors:  *FlagEigenNormalize,
+		CombineVectors:    *FlagEigenCombine,
+	}
+}
+
+// canonical is the instance used in debug mode
+var canonical = MustTestInstance("canonical4").Dist
+
 func main() {
 	flag.Parse()
 	rand.Seed(1)
+	err := flagEigenOptions().Validate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	switch *FlagSymmetrize {
+	case "", SymmetrizeMin, SymmetrizeMax, SymmetrizeAvg:
+	default:
+		fmt.Fprintf(os.Stderr, "unknown symmetrize method %q\n", *FlagSymmetrize)
+		os.Exit(2)
+	}
+	if *FlagWatch && (*FlagInput == "" || *FlagInput == "-") {
+		fmt.Fprintln(os.Stderr, "-watch requires an -input file")
+		os.Exit(2)
+	}
+	// result is where the final result is written, in quiet mode everything
+	// else written to stdout is discarded
+	result := io.Writer(os.Stdout)
+	if *FlagQuiet {
+		null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		result, os.Stdout = os.Stdout, null
+		*FlagOutput = "json"
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Fprintln(os.Stderr, r)
+				os.Exit(1)
+			}
+		}()
+	}
 	if *FlagDebug {
-		test()
+		r := test(rand.New(rand.NewSource(1)))
+		if *FlagQuiet {
+			writeJSON(result, r)
+		}
 		return
 	}
-	eigenCount, nnCount := 0, 0
-	for i := 0; i < 1024; i++ {
-		eigen, nn := test()
-		if eigen {
-			eigenCount++
+	if *FlagListMethods {
+		err = ListMethods(result)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-		if nn {
-			nnCount++
+		return
+	}
+	if *FlagVerify != "" {
+		instance := loadInstance()
+		tour, err := readTourFile(*FlagVerify)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		err = VerifyTour(instance.Dist, instance.Size, tour)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(result, "tour is valid with cost %v\n", tour.Cost)
+		return
+	}
+	if *FlagResume != "" {
+		instance := loadInstance()
+		checkpoint, err := ReadCheckpoint(*FlagResume)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		tour, err := ResumeSimulatedAnnealing(instance.Dist, instance.Size, checkpoint)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		output(result, []RankedResult{{Name: "sa", Tour: tour, Rank: 1}}, &instance)
+		return
+	}
+	if *FlagDryRun {
+		instance := loadInstance()
+		err = DryRun(result, &instance)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *FlagTSPLIB != "" {
+		results := RunTSPLIBBenchmark(strings.Split(*FlagTSPLIB, ","), nil, *FlagTimeLimit)
+		file, err := os.Create("benchmark_results.csv")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		err = WriteBenchmarkResults(file, results)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *FlagInput != "" {
+		instance := loadInstance()
+		output(result, CompareSolvers(SizedSolvers(DefaultSolvers(), instance.Size), instance.Dist, instance.Size), &instance)
+		if *FlagWatch {
+			err = Watch(*FlagInput, WatchInterval, nil, func() {
+				instance, err := ReadInstanceFile(*FlagInput, *FlagFormat)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					return
+				}
+				output(result, CompareSolvers(SizedSolvers(DefaultSolvers(), instance.Size), instance.Dist, instance.Size), instance)
+			})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+	if *FlagCompare {
+		output(result, CompareSolvers(DefaultSolvers(), canonical, Size), nil)
+		return
+	}
+	if *FlagProfile != "" {
+		output, err := os.Create(*FlagProfile)
+		if err != nil {
+			panic(err)
+		}
+		defer output.Close()
+		err = pprof.StartCPUProfile(output)
+		if err != nil {
+			panic(err)
+		}
+	}
+	trials := RunTrials(1024, *FlagParallel, 1)
+	if *FlagProfile != "" {
+		pprof.StopCPUProfile()
+	}
+	if *FlagMemProfile != "" {
+		output, err := os.Create(*FlagMemProfile)
+		if err != nil {
+			panic(err)
+		}
+		defer output.Close()
+		runtime.GC()
+		err = pprof.WriteHeapProfile(output)
+		if err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(float64(trials.EigenCount)/1024.0, float64(trials.NNCount)/1024.0)
+	PrintStatistics(os.Stdout, Summarize(trials.Results))
+	fmt.Println()
+	PrintCorrelations(os.Stdout, Correlations(trials.Results))
+	err = PlotCostHistograms(trials.Results, 32)
+	if err != nil {
+		panic(err)
+	}
+	if *FlagPlotParallel {
+		err = PlotParallelCoordinates(trials.Results, "parallel.png")
+		if err != nil {
+			panic(err)
+		}
+	}
+	if *FlagQuiet {
+		writeJSON(result, Summarize(trials.Results))
+	}
+}
+
+// loadInstance loads the -input instance, or the debug instance if there is
+// no input, exiting on failure
+func loadInstance() Instance {
+	if *FlagInput == "" {
+		return MustTestInstance("canonical4")
+	}
+	instance, err := ReadInstanceFile(*FlagInput, *FlagFormat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return *instance
+}
+
+// readTourFile reads a json tour from the file
+func readTourFile(path string) (Tour, error) {
+	input, err := os.Open(path)
+	if err != nil {
+		return Tour{}, err
+	}
+	defer input.Close()
+	return ReadTourJSON(input)
+}
+
+// writeJSON writes v to w as a line of json, exiting on failure
+func writeJSON(w io.Writer, v interface{}) {
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// output writes the best tour of a comparison of the instance to w in the
+// -output format, or all of the results as a table if there is no format. The
+// cities of text output are named by the labels if there are any, and json
+// output has the labels and metadata of the cities. The instance is nil for
+// the canonical instance.
+func output(w io.Writer, results []RankedResult, instance *Instance) {
+	var labels CityLabels
+	if instance != nil {
+		labels = instance.Labels
+	}
+	if *FlagOutput == "" {
+		printComparison(results, labels)
+		return
+	}
+	var err error
+	if *FlagOutput == "text" && labels != nil {
+		err = WriteTourLabeled(w, results[0].Tour, labels)
+	} else if *FlagOutput == "json" && instance != nil {
+		err = WriteInstanceTourJSON(w, results[0].Tour, instance)
+	} else {
+		err = WriteTour(w, results[0].Tour, *FlagOutput)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// printComparison prints the ranked results of a comparison as a table, the
+// cities are named by the labels if there are any
+func printComparison(results []RankedResult, labels CityLabels) {
+	fmt.Printf("%-4s %-20s %10s %s\n", "Rank", "Solver", "Cost", "Route")
+	for _, result := range results {
+		var route interface{} = result.Tour.Route
+		if labels != nil {
+			route = labels.Route(result.Tour.Route)
 		}
+		fmt.Printf("%-4d %-20s %10.2f %v\n", result.Rank, result.Name, result.Tour.Cost, route)
 	}
-	fmt.Println(float64(eigenCount)/1024.0, float64(nnCount)/1024.0)
 }
 
-// Search searches for a solution to the traveling salesman problem
+// Search searches for a solution to the traveling salesman problem, the
+// number of cities is the square root of the length of a
 func Search(a []float64) (float64, []int) {
-	var search func(sum float64, i int, nodes []int, visited [Size]bool) (float64, []int)
-	search = func(sum float64, i int, nodes []int, visited [Size]bool) (float64, []int) {
+	size := int(math.Sqrt(float64(len(a))))
+	var search func(sum float64, i int, nodes []int, visited []bool) (float64, []int)
+	search = func(sum float64, i int, nodes []int, visited []bool) (float64, []int) {
 		smallest, cities := math.MaxFloat64, nodes
 		visited[i] = true
+		defer func() {
+			visited[i] = false
+		}()
 		skipped := true
 		for j, skip := range visited {
 			if skip {
 				continue
 			}
 			skipped = false
-			value, x := search(sum+a[i*Size+j], j, append(nodes, j), visited)
+			next := append(append(make([]int, 0, size+1), nodes...), j)
+			value, x := search(sum+a[i*size+j], j, next, visited)
 			if value < smallest {
 				smallest, cities = value, x
 			}
 		}
 		if skipped {
-			return sum + a[i*Size+nodes[0]], append(cities, nodes[0])
+			return sum + a[i*size+nodes[0]], append(cities, nodes[0])
 		}
 		return smallest, cities
 	}
-	sum, nodes := search(0, 0, []int{0}, [Size]bool{})
-	for i := 1; i < Size; i++ {
-		s, n := search(0, i, []int{i}, [Size]bool{})
+	sum, nodes := search(0, 0, []int{0}, make([]bool, size))
+	for i := 1; i < size; i++ {
+		s, n := search(0, i, []int{i}, make([]bool, size))
 		if s < sum {
 			sum, nodes = s, n
 		}
@@ -136,9 +442,25 @@ func PageRank(a []float64) (float64, []uint64) {
 	return total, pageNodes
 }
 
-// Eigen uses eigen vectors to solve the traveling salesman problem
+// Eigen uses eigen vectors to solve the traveling salesman problem with the
+// options set by the -eigen-* flags
 func Eigen(a []float64) (*mat.CDense, float64, []int) {
-	adjacency := mat.NewDense(Size, Size, a)
+	return EigenWithOptions(a, flagEigenOptions())
+}
+
+// EigenImag is Eigen using the imaginary parts of the scaled eigenvectors
+func EigenImag(a []float64) (*mat.CDense, float64, []int) {
+	options := flagEigenOptions()
+	options.Component = ComponentImag
+	return EigenWithOptions(a, options)
+}
+
+// EigenWithOptions uses eigen vectors to solve the traveling salesman problem
+// with the eigenvalues selected by the options, the number of cities is the
+// square root of the length of a
+func EigenWithOptions(a []float64, options EigenOptions) (*mat.CDense, float64, []int) {
+	size := int(math.Sqrt(float64(len(a))))
+	adjacency := mat.NewDense(size, size, a)
 	var eig mat.Eigen
 	ok := eig.Factorize(adjacency, mat.EigenBoth)
 	if !ok {
@@ -146,6 +468,11 @@ func Eigen(a []float64) (*mat.CDense, float64, []int) {
 	}
 
 	values := eig.Values(nil)
+	if err := options.Validate(); err != nil {
+		panic(err)
+	}
+	selected, _ := SelectEigenvalues(values, options)
+	part := options.part()
 	if *FlagDebug {
 		for i, value := range values {
 			fmt.Println(i, value, cmplx.Abs(value), cmplx.Phase(value))
@@ -155,9 +482,12 @@ func Eigen(a []float64) (*mat.CDense, float64, []int) {
 
 	vectors := mat.CDense{}
 	eig.VectorsTo(&vectors)
+	if options.NormalizeVectors {
+		normalizeColumns(&vectors)
+	}
 	if *FlagDebug {
-		for i := 0; i < Size; i++ {
-			for j := 0; j < Size; j++ {
+		for i := 0; i < size; i++ {
+			for j := 0; j < size; j++ {
 				fmt.Printf("%f ", vectors.At(i, j))
 			}
 			fmt.Printf("\n")
@@ -167,9 +497,12 @@ func Eigen(a []float64) (*mat.CDense, float64, []int) {
 
 	leftVectors := mat.CDense{}
 	eig.LeftVectorsTo(&leftVectors)
+	if options.NormalizeVectors {
+		normalizeColumns(&leftVectors)
+	}
 	if *FlagDebug {
-		for i := 0; i < Size; i++ {
-			for j := 0; j < Size; j++ {
+		for i := 0; i < size; i++ {
+			for j := 0; j < size; j++ {
 				fmt.Printf("%f ", leftVectors.At(i, j))
 			}
 			fmt.Printf("\n")
@@ -177,66 +510,67 @@ func Eigen(a []float64) (*mat.CDense, float64, []int) {
 		fmt.Printf("\n")
 	}
 
-	distances := make([]float64, Size*Size)
-	for i := 0; i < Size; i++ {
-		for j := 0; j < Size; j++ {
+	distances := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
 			if i == j {
 				continue
 			}
 			sum := 0.0
-			for k := 0; k < Size; k++ {
-				x := real(values[k]*vectors.At(i, k)) - real(values[k]*vectors.At(j, k))
+			for _, k := range selected {
+				x := part(values[k]*vectors.At(i, k) - values[k]*vectors.At(j, k))
 				sum += x * x
 			}
-			distances[i*Size+j] = math.Sqrt(sum) * a[i*Size+j]
+			distances[i*size+j] = math.Sqrt(sum) * a[i*size+j]
 		}
 	}
 	if *FlagDebug {
-		for i := 0; i < Size; i++ {
-			for j := 0; j < Size; j++ {
-				fmt.Printf("%f ", distances[i*Size+j])
+		for i := 0; i < size; i++ {
+			for j := 0; j < size; j++ {
+				fmt.Printf("%f ", distances[i*size+j])
 			}
 			fmt.Printf("\n")
 		}
 	}
 
-	leftDistances := make([]float64, Size*Size)
-	for i := 0; i < Size; i++ {
-		for j := 0; j < Size; j++ {
+	leftDistances := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
 			if i == j {
 				continue
 			}
 			sum := 0.0
-			for k := 0; k < Size; k++ {
-				x := real(values[k]*leftVectors.At(i, k)) - real(values[k]*leftVectors.At(j, k))
+			for _, k := range selected {
+				x := part(values[k]*leftVectors.At(i, k) - values[k]*leftVectors.At(j, k))
 				sum += x * x
 			}
-			leftDistances[i*Size+j] = math.Sqrt(sum) * a[i*Size+j]
+			leftDistances[i*size+j] = math.Sqrt(sum) * a[i*size+j]
 		}
 	}
+	distances, leftDistances = combineDistances(distances, leftDistances, options.CombineVectors)
 	if *FlagDebug {
-		for i := 0; i < Size; i++ {
-			for j := 0; j < Size; j++ {
-				fmt.Printf("%f ", leftDistances[i*Size+j])
+		for i := 0; i < size; i++ {
+			for j := 0; j < size; j++ {
+				fmt.Printf("%f ", leftDistances[i*size+j])
 			}
 			fmt.Printf("\n")
 		}
 	}
 
 	minTotal, minLoop := math.MaxFloat64, make([]int, 0, 8)
-	for offset := 0; offset < Size; offset++ {
-		visited := [Size]bool{}
+	for offset := 0; offset < size; offset++ {
+		visited := make([]bool, size)
 		state := offset
 		visited[state] = true
 		total, loop := 0.0, make([]int, 0, 8)
 		loop = append(loop, state)
-		for i := 0; i < Size-1; i++ {
+		for i := 0; i < size-1; i++ {
 			min, k := math.MaxFloat64, 0
-			for j := 0; j < Size; j++ {
+			for j := 0; j < size; j++ {
 				if j == state || visited[j] {
 					continue
 				}
-				if v := distances[state*Size+j]; v < min {
+				if v := distances[state*size+j]; v < min {
 					min, k = v, j
 				}
 			}
@@ -247,27 +581,27 @@ func Eigen(a []float64) (*mat.CDense, float64, []int) {
 		loop = append(loop, loop[0])
 		last := loop[0]
 		for _, node := range loop[1:] {
-			total += a[last*Size+node]
+			total += a[last*size+node]
 			last = node
 		}
-		if total < minTotal && loop[0] == loop[Size] {
+		if total < minTotal && loop[0] == loop[size] {
 			minTotal, minLoop = total, loop
 		}
 	}
 
-	for offset := 0; offset < Size; offset++ {
-		visited := [Size]bool{}
+	for offset := 0; offset < size; offset++ {
+		visited := make([]bool, size)
 		state := offset
 		visited[state] = true
 		total, loop := 0.0, make([]int, 0, 8)
 		loop = append(loop, state)
-		for i := 0; i < Size-1; i++ {
+		for i := 0; i < size-1; i++ {
 			min, k := math.MaxFloat64, 0
-			for j := 0; j < Size; j++ {
+			for j := 0; j < size; j++ {
 				if j == state || visited[j] {
 					continue
 				}
-				if v := leftDistances[state*Size+j]; v < min {
+				if v := leftDistances[state*size+j]; v < min {
 					min, k = v, j
 				}
 			}
@@ -278,10 +612,10 @@ func Eigen(a []float64) (*mat.CDense, float64, []int) {
 		loop = append(loop, loop[0])
 		last := loop[0]
 		for _, node := range loop[1:] {
-			total += a[last*Size+node]
+			total += a[last*size+node]
 			last = node
 		}
-		if total < minTotal && loop[0] == loop[Size] {
+		if total < minTotal && loop[0] == loop[size] {
 			minTotal, minLoop = total, loop
 		}
 	}
@@ -522,6 +856,12 @@ func EigenKMeans(a []float64) (float64, []int) {
 
 // NearestNeighbor uses nearest neighbor to solve the traveling salesman problem
 func NearestNeighbor(a []float64) (float64, []int) {
+	return explainedNearestNeighborAll(a, nil)
+}
+
+// explainedNearestNeighborAll is NearestNeighbor explaining each move from
+// every starting city with the explainer
+func explainedNearestNeighborAll(a []float64, e *explainer) (float64, []int) {
 	distances := a
 	minTotal, minLoop := math.MaxFloat64, make([]int, 0, 8)
 	for offset := 0; offset < Size; offset++ {
@@ -540,6 +880,9 @@ func NearestNeighbor(a []float64) (float64, []int) {
 					min, k = v, j
 				}
 			}
+			if e.enabled() {
+				explainNearest(e, distances, Size, visited[:], state, k)
+			}
 			state = k
 			visited[state] = true
 			loop = append(loop, state)
@@ -557,9 +900,234 @@ func NearestNeighbor(a []float64) (float64, []int) {
 	return minTotal, minLoop
 }
 
+// DefaultMaxGradNorm is the gradient norm Neural clips the gradient to
+const DefaultMaxGradNorm = 1.0
+
+// NeuralOptions are the options for Neural
+type NeuralOptions struct {
+	// TrackEmbeddings snapshots the embedding every SnapshotInterval
+	// iterations and animates it to embedding_animation.gif
+	TrackEmbeddings bool
+	// MaxGradNorm is the norm the gradient is scaled down to when it is
+	// larger, zero disables clipping. Neural uses DefaultMaxGradNorm.
+	MaxGradNorm float64
+	// WeightInit is the initialization of the embedding: he, xavier,
+	// orthogonal or zero, empty is he
+	WeightInit string
+	// BatchNorm normalizes the hidden layer before the sigmoid with a learned
+	// scale and shift
+	BatchNorm bool
+	// Seed seeds the initial weights, zero uses the global random number
+	// generator
+	Seed int64
+}
+
+const (
+	// WeightInitHe draws the weights from N(0, 2/n)
+	WeightInitHe = "he"
+	// WeightInitXavier draws the weights from N(0, 1/n)
+	WeightInitXavier = "xavier"
+	// WeightInitOrthogonal makes the columns of the weights a random
+	// orthonormal basis
+	WeightInitOrthogonal = "orthogonal"
+	// WeightInitZero sets the weights to zero
+	WeightInitZero = "zero"
+)
+
+// neuralWeights initializes the weights of a layer with cols inputs and rows
+// outputs stored row by row, drawing from rng or the global random number
+// generator if rng is nil
+func neuralWeights(cols, rows int, method string, rng *rand.Rand) []float64 {
+	normal := rand.NormFloat64
+	if rng != nil {
+		normal = rng.NormFloat64
+	}
+	weights := make([]float64, cols*rows)
+	switch method {
+	case WeightInitHe, "":
+		factor := math.Sqrt(2.0 / float64(cols))
+		for i := range weights {
+			weights[i] = normal() * factor
+		}
+	case WeightInitXavier:
+		factor := math.Sqrt(1.0 / float64(cols))
+		for i := range weights {
+			weights[i] = normal() * factor
+		}
+	case WeightInitOrthogonal:
+		random := mat.NewDense(rows, cols, nil)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				random.Set(i, j, normal())
+			}
+		}
+		var qr mat.QR
+		qr.Factorize(random)
+		var q mat.Dense
+		qr.QTo(&q)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				weights[i*cols+j] = q.At(i, j)
+			}
+		}
+	case WeightInitZero:
+	default:
+		panic(fmt.Sprintf("unknown weight initialization %q", method))
+	}
+	return weights
+}
+
 // Neural uses a neural network to solve the traveling salesman problem
 func Neural(a []float64) (float64, []int) {
-	Scale := 4
+	return NeuralWithOptions(a, NeuralOptions{MaxGradNorm: DefaultMaxGradNorm})
+}
+
+// NeuralWithOptions is Neural with options
+func NeuralWithOptions(a []float64, opts NeuralOptions) (float64, []int) {
+	Scale := neuralScale
+	w, snapshots, _ := neuralTrain(a, Scale, 1024, opts)
+	if opts.TrackEmbeddings {
+		err := AnimateEmbeddings(snapshots, Scale, "embedding_animation.gif")
+		if err != nil {
+			panic(err)
+		}
+	}
+	return neuralDecode(a, w, Scale)
+}
+
+// NeuralEnsemble trains numNetworks networks concurrently, network n with the
+// seed opts.Seed+n+1, and returns the best of their tours. The embeddings
+// aren't tracked.
+func NeuralEnsemble(dist []float64, size int, numNetworks int, opts NeuralOptions) Tour {
+	if size != Size {
+		panic("solver requires Size cities")
+	}
+	tours := make([]Tour, numNetworks)
+	var wg sync.WaitGroup
+	for n := range tours {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			options := opts
+			options.Seed = opts.Seed + int64(n) + 1
+			options.TrackEmbeddings = false
+			cost, route := NeuralWithOptions(dist, options)
+			tours[n] = Tour{Cost: cost, Route: route}
+		}(n)
+	}
+	wg.Wait()
+	return BestOf(tours...)
+}
+
+// neuralScale is the number of embedding dimensions per city of Neural
+const neuralScale = 4
+
+// NeuralSolver is a Neural solver whose weights persist between training and
+// solving, Reset reinitializes them for another trial
+type NeuralSolver struct {
+	Options NeuralOptions
+	weights []float64
+	trained bool
+}
+
+// NewNeuralSolver returns a NeuralSolver with weights initialized from
+// opts.Seed
+func NewNeuralSolver(opts NeuralOptions) *NeuralSolver {
+	n := &NeuralSolver{Options: opts}
+	n.Reset(opts.Seed)
+	return n
+}
+
+// Reset reinitializes the weights from the seed, zero uses the global random
+// number generator
+func (n *NeuralSolver) Reset(seed int64) {
+	var rng *rand.Rand
+	if seed != 0 {
+		rng = rand.New(rand.NewSource(seed))
+	}
+	n.Options.Seed = seed
+	n.weights = neuralWeights(Size, neuralScale*Size, n.Options.WeightInit, rng)
+	n.trained = false
+}
+
+// Train trains the weights on the instance, continuing from the current
+// weights
+func (n *NeuralSolver) Train(dist []float64, size int) error {
+	if size != Size {
+		return fmt.Errorf("neural solver requires %d cities, got %d", Size, size)
+	}
+	w, snapshots, _ := neuralTrainFrom(dist, neuralScale, 1024, n.weights, n.Options)
+	if n.Options.TrackEmbeddings {
+		err := AnimateEmbeddings(snapshots, neuralScale, "embedding_animation.gif")
+		if err != nil {
+			return err
+		}
+	}
+	n.weights, n.trained = w, true
+	return nil
+}
+
+// Solve decodes a tour from the embedding, training it first if it isn't
+// trained
+func (n *NeuralSolver) Solve(dist []float64, size int) Tour {
+	start := time.Now()
+	if !n.trained {
+		err := n.Train(dist, size)
+		if err != nil {
+			panic(err)
+		}
+	}
+	cost, route := neuralDecode(dist, n.weights, neuralScale)
+	return Tour{Cost: cost, Route: route, Elapsed: time.Since(start)}
+}
+
+// SupportsWarmStart is false
+func (n *NeuralSolver) SupportsWarmStart() bool {
+	return false
+}
+
+// Embed returns a copy of the embedding of the city, a vector of
+// neuralScale*Size dimensions
+func (n *NeuralSolver) Embed(city int) []float64 {
+	if city < 0 || city >= Size {
+		panic(fmt.Sprintf("city %d out of range", city))
+	}
+	embedding := make([]float64, neuralScale*Size)
+	for k := range embedding {
+		embedding[k] = n.weights[city+k*Size]
+	}
+	return embedding
+}
+
+// EmbeddingMatrix returns the embedding with a row for each city
+func (n *NeuralSolver) EmbeddingMatrix() *mat.Dense {
+	embedding := mat.NewDense(Size, neuralScale*Size, nil)
+	for i := 0; i < Size; i++ {
+		embedding.SetRow(i, n.Embed(i))
+	}
+	return embedding
+}
+
+// neuralEmbedding trains the embedding of the cities used by Neural, the
+// embedding of city i is w[i+k*Size] for k < Scale*Size
+func neuralEmbedding(a []float64, Scale int) []float64 {
+	w, _, _ := neuralTrain(a, Scale, 1024, NeuralOptions{MaxGradNorm: DefaultMaxGradNorm})
+	return w
+}
+
+// neuralTrain trains the embedding for at most iterations, returning the
+// snapshots of the embedding if they are tracked and the number of iterations
+// before the cost converged
+func neuralTrain(a []float64, Scale, iterations int, opts NeuralOptions) ([]float64, [][]float64, int) {
+	var rng *rand.Rand
+	if opts.Seed != 0 {
+		rng = rand.New(rand.NewSource(opts.Seed))
+	}
+	return neuralTrainFrom(a, Scale, iterations, neuralWeights(Size, Scale*Size, opts.WeightInit, rng), opts)
+}
+
+// neuralTrainFrom is neuralTrain starting from the weights of the embedding
+func neuralTrainFrom(a []float64, Scale, iterations int, weights []float64, opts NeuralOptions) ([]float64, [][]float64, int) {
 	set := tf64.NewSet()
 	set.Add("A", Size, Size)
 	set.Add("X", Size, Scale*Size)
@@ -571,25 +1139,38 @@ func Neural(a []float64) (float64, []int) {
 	}
 
 	w = set.Weights[1]
-	factor := math.Sqrt(2.0 / float64(w.S[0]))
-	for i := 0; i < cap(w.X); i++ {
-		w.X = append(w.X, rand.NormFloat64()*factor)
-	}
+	w.X = append(w.X, weights...)
 
 	set.Weights[2].X = set.Weights[2].X[:cap(set.Weights[2].X)]
 
+	hidden := tf64.Mul(set.Get("A"), set.Get("X"))
+	if opts.BatchNorm {
+		set.Add("gamma", Scale*Size)
+		set.Add("beta", Scale*Size)
+		gamma, beta := set.ByName["gamma"], set.ByName["beta"]
+		for i := 0; i < Scale*Size; i++ {
+			gamma.X = append(gamma.X, 1)
+			beta.X = append(beta.X, 0)
+		}
+		hidden = tf64.Op(batchNorm)(hidden, set.Get("gamma"), set.Get("beta"))
+	}
+
 	deltas := make([][]float64, 0, 8)
 	for _, p := range set.Weights {
 		deltas = append(deltas, make([]float64, len(p.X)))
 	}
 
-	l1 := tf64.Sigmoid(tf64.Add(tf64.Mul(set.Get("A"), set.Get("X")), set.Get("B")))
+	l1 := tf64.Sigmoid(tf64.Add(hidden, set.Get("B")))
 	cost := tf64.Avg(tf64.Quadratic(l1, set.Get("X")))
 
-	alpha, eta, iterations := .3, .3, 1024
+	alpha, eta := .3, .3
 	points := make(plotter.XYs, 0, iterations)
+	var snapshots [][]float64
 	i := 0
 	for i < iterations {
+		if opts.TrackEmbeddings && i%SnapshotInterval == 0 {
+			snapshots = append(snapshots, append([]float64{}, set.Weights[1].X...))
+		}
 		total := 0.0
 		set.Zero()
 
@@ -602,8 +1183,8 @@ func Neural(a []float64) (float64, []int) {
 		}
 		norm := math.Sqrt(sum)
 		scaling := 1.0
-		if norm > 1 {
-			scaling = 1 / norm
+		if opts.MaxGradNorm > 0 && norm > opts.MaxGradNorm {
+			scaling = opts.MaxGradNorm / norm
 		}
 
 		for j, w := range set.Weights[1:] {
@@ -643,21 +1224,13 @@ func Neural(a []float64) (float64, []int) {
 			panic(err)
 		}
 	}
+	return w.X, snapshots, i
+}
 
-	distances := make([]float64, Size*Size)
-	for i := 0; i < Size; i++ {
-		for j := 0; j < Size; j++ {
-			if i == j {
-				continue
-			}
-			sum := 0.0
-			for k := 0; k < Scale*Size; k++ {
-				x := w.X[i+k*Size] - w.X[j+k*Size]
-				sum += x * x
-			}
-			distances[i*Size+j] = math.Sqrt(sum)
-		}
-	}
+// neuralDecode decodes a tour with nearest neighbor on the euclidean
+// distances between the cities in the embedding
+func neuralDecode(a []float64, w []float64, Scale int) (float64, []int) {
+	distances := embeddingDistances(w, Scale)
 	if *FlagDebug {
 		for i := 0; i < Size; i++ {
 			for j := 0; j < Size; j++ {
@@ -665,6 +1238,7 @@ func Neural(a []float64) (float64, []int) {
 			}
 			fmt.Printf("\n")
 		}
+		fmt.Println("spearman", EmbeddingCorrelation(a, w, Scale))
 	}
 	minTotal, minLoop := math.MaxFloat64, make([]int, 0, 8)
 	for offset := 0; offset < Size; offset++ {
@@ -674,14 +1248,15 @@ func Neural(a []float64) (float64, []int) {
 		total, loop := 0.0, make([]int, 0, 8)
 		loop = append(loop, state)
 		for i := 0; i < Size; i++ {
-			min, k := math.MaxFloat64, 0
+			min, k := math.MaxFloat64, -1
 			done := true
 			for j := 0; j < Size; j++ {
 				if j == state || visited[j] {
 					continue
 				}
 				done = false
-				if v := distances[state*Size+j]; v < min {
+				// an embedding that diverged has NaN distances, which are never less than min
+				if v := distances[state*Size+j]; v < min || k == -1 {
 					min, k = v, j
 				}
 			}
@@ -708,8 +1283,71 @@ func Neural(a []float64) (float64, []int) {
 	return minTotal, minLoop
 }
 
+// embeddingDistances are the euclidean distances between the cities in the
+// embedding
+func embeddingDistances(w []float64, Scale int) []float64 {
+	distances := make([]float64, Size*Size)
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			if i == j {
+				continue
+			}
+			sum := 0.0
+			for k := 0; k < Scale*Size; k++ {
+				x := w[i+k*Size] - w[j+k*Size]
+				sum += x * x
+			}
+			distances[i*Size+j] = math.Sqrt(sum)
+		}
+	}
+	return distances
+}
+
+// EmbeddingCorrelation is the Spearman correlation between the distances
+// between the cities and their distances in the embedding
+func EmbeddingCorrelation(a []float64, w []float64, Scale int) float64 {
+	distances := embeddingDistances(w, Scale)
+	x, y := make([]float64, 0, Size*Size), make([]float64, 0, Size*Size)
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			if i != j {
+				x, y = append(x, a[i*Size+j]), append(y, distances[i*Size+j])
+			}
+		}
+	}
+	return Spearman(x, y)
+}
+
+// Spearman is the rank correlation of x and y, ties share their average rank
+func Spearman(x, y []float64) float64 {
+	return stat.Correlation(ranks(x), ranks(y), nil)
+}
+
+// ranks ranks the values from 1, ties share their average rank
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+	ranked := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j < len(order) && values[order[j]] == values[order[i]] {
+			j++
+		}
+		for k := i; k < j; k++ {
+			ranked[order[k]] = float64(i+j+1) / 2
+		}
+		i = j
+	}
+	return ranked
+}
+
 // Neural2 uses a neural network to solve the traveling salesman problem
-func Neural2(a []float64) (float64, []int) {
+func Neural2(a []float64, rng *rand.Rand) (float64, []int) {
 	data := tf64.NewSet()
 	data.Add("nodes", Size, Size*Size)
 	data.Add("distances", 1, Size*Size)
@@ -739,7 +1377,7 @@ func Neural2(a []float64) (float64, []int) {
 	for _, w := range set.Weights[:2] {
 		factor := math.Sqrt(2.0 / float64(w.S[0]))
 		for i := 0; i < cap(w.X); i++ {
-			w.X = append(w.X, rand.NormFloat64()*factor)
+			w.X = append(w.X, rng.NormFloat64()*factor)
 		}
 	}
 	for _, w := range set.Weights[2:] {
@@ -897,18 +1535,13 @@ func Neural2(a []float64) (float64, []int) {
 	return minTotal, minLoop
 }
 
-func test() (bool, bool) {
-	a := []float64{
-		0, 20, 42, 35,
-		20, 0, 30, 34,
-		42, 30, 0, 12,
-		35, 34, 12, 0,
-	}
+func test(rng *rand.Rand) TestResult {
+	a := canonical
 	if !*FlagDebug {
 		a = make([]float64, Size*Size)
 		for i := 0; i < Size; i++ {
 			for j := i + 1; j < Size; j++ {
-				value := float64(rand.Intn(8) + 1)
+				value := float64(rng.Intn(8) + 1)
 				a[i*Size+j] = value
 				a[j*Size+i] = value
 			}
@@ -923,13 +1556,24 @@ func test() (bool, bool) {
 		}
 	}
 
+	elapsed := make([]time.Duration, len(Algorithms))
 	total0, loop0 := Search(a)
+	start := time.Now()
 	total1, loop1 := PageRank(a)
+	elapsed[AlgorithmPageRank] = time.Since(start)
+	start = time.Now()
 	vectors, total2, loop2 := Eigen(a)
+	elapsed[AlgorithmEigen] = time.Since(start)
+	start = time.Now()
 	total3, loop3 := Eigen2(a)
+	elapsed[AlgorithmEigen2] = time.Since(start)
+	start = time.Now()
 	total4, loop4 := NearestNeighbor(a)
+	elapsed[AlgorithmNearestNeighbor] = time.Since(start)
 	EigenKMeans(a)
-	total5, loop5 := Neural2(a)
+	start = time.Now()
+	total5, loop5 := Neural2(a, rng)
+	elapsed[AlgorithmNeural2] = time.Since(start)
 
 	ranks := mat.NewDense(Size, Size, nil)
 	for i := 0; i < Size; i++ {
@@ -937,6 +1581,21 @@ func test() (bool, bool) {
 			ranks.Set(i, j, real(vectors.At(i, j)))
 		}
 	}
+	start = time.Now()
+	pca := NearestNeighborPCA(ranks, a, Size)
+	elapsed[AlgorithmNearestNeighborPCA] = time.Since(start)
+	start = time.Now()
+	hits := HITSTour(a, Size, 64)
+	elapsed[AlgorithmHITS] = time.Since(start)
+	start = time.Now()
+	laplacian := LaplacianEigen(a, Size)
+	elapsed[AlgorithmLaplacian] = time.Since(start)
+	start = time.Now()
+	weighted := PageRankWeightedNN(a, Size)
+	elapsed[AlgorithmPageRankWeightedNN] = time.Since(start)
+	start = time.Now()
+	hybrid := HybridEigenNN(a, Size)
+	elapsed[AlgorithmHybridEigenNN] = time.Since(start)
 	if *FlagDebug {
 		fmt.Println("Search", total0, loop0)
 		fmt.Println("PageRank", total1, loop1)
@@ -944,24 +1603,60 @@ func test() (bool, bool) {
 		fmt.Println("Eigen2", total3, loop3)
 		fmt.Println("NearestNeighbor", total4, loop4)
 		fmt.Println("Neural2", total5, loop5)
-		Reduction("results", ranks)
-	}
-
-	return total0 == total5, total0 == total4
+		fmt.Println("NearestNeighborPCA", pca.Cost, pca.Route)
+		fmt.Println("HITS", hits.Cost, hits.Route)
+		fmt.Println("Laplacian", laplacian.Cost, laplacian.Route)
+		fmt.Println("PageRankWeightedNN", weighted.Cost, weighted.Route)
+		fmt.Println("HybridEigenNN", hybrid.Cost, hybrid.Route)
+		switch *FlagReduction {
+		case "tsne":
+			ReductionTSNE("results", ranks, 2)
+		default:
+			Reduction("results", ranks)
+		}
+	}
+
+	result := TestResult{
+		Optimal: total0,
+		Costs:   make([]float64, len(Algorithms)),
+		Elapsed: elapsed,
+	}
+	result.Costs[AlgorithmPageRank] = total1
+	result.Costs[AlgorithmEigen] = total2
+	result.Costs[AlgorithmEigen2] = total3
+	result.Costs[AlgorithmNearestNeighbor] = total4
+	result.Costs[AlgorithmNeural2] = total5
+	result.Costs[AlgorithmNearestNeighborPCA] = pca.Cost
+	result.Costs[AlgorithmHITS] = hits.Cost
+	result.Costs[AlgorithmLaplacian] = laplacian.Cost
+	result.Costs[AlgorithmPageRankWeightedNN] = weighted.Cost
+	result.Costs[AlgorithmHybridEigenNN] = hybrid.Cost
+	return result
 }
 
-// Reduction reduces the matrix
-func Reduction(name string, ranks *mat.Dense) {
+// project projects the rows of the matrix onto their first two principal
+// components
+func project(ranks *mat.Dense) (*mat.Dense, error) {
 	var pc stat.PC
 	ok := pc.PrincipalComponents(ranks, nil)
 	if !ok {
-		panic("PrincipalComponents failed")
+		return nil, errors.New("PrincipalComponents failed")
 	}
 	k := 2
+	_, c := ranks.Dims()
 	var proj mat.Dense
 	var vec mat.Dense
 	pc.VectorsTo(&vec)
-	proj.Mul(ranks, vec.Slice(0, Size, 0, k))
+	proj.Mul(ranks, vec.Slice(0, c, 0, k))
+	return &proj, nil
+}
+
+// Reduction reduces the matrix
+func Reduction(name string, ranks *mat.Dense) {
+	proj, err := project(ranks)
+	if err != nil {
+		panic(err)
+	}
 
 	fmt.Printf("\n")
 	points := make(plotter.XYs, 0, 8)
@@ -1016,3 +1711,96 @@ func Reduction(name string, ranks *mat.Dense) {
 		fmt.Fprintf(output, "%f %f\n", point.X, point.Y)
 	}
 }
+
+// ReductionLabeled plots the rows of the matrix reduced to two dimensions with
+// each point labeled, the labels default to the row indexes. Each label is
+// placed on the side of its point facing away from the center of the points so
+// that labels of nearby points don't overlap.
+func ReductionLabeled(name string, ranks *mat.Dense, labels []string) error {
+	proj, err := project(ranks)
+	if err != nil {
+		return err
+	}
+	r, _ := ranks.Dims()
+	if labels == nil {
+		labels = make([]string, r)
+		for i := range labels {
+			labels[i] = strconv.Itoa(i)
+		}
+	}
+	if len(labels) != r {
+		return fmt.Errorf("%d labels for %d points", len(labels), r)
+	}
+
+	points := make(plotter.XYs, 0, r)
+	cx, cy := 0.0, 0.0
+	for i := 0; i < r; i++ {
+		x, y := proj.At(i, 0), proj.At(i, 1)
+		points = append(points, plotter.XY{X: x, Y: y})
+		cx += x / float64(r)
+		cy += y / float64(r)
+	}
+
+	p := plot.New()
+
+	p.Title.Text = "x vs y"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	scatter, err := plotter.NewScatter(points)
+	if err != nil {
+		return err
+	}
+	scatter.GlyphStyle.Radius = vg.Length(3)
+	scatter.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(scatter)
+
+	for i, point := range points {
+		label, err := plotter.NewLabels(plotter.XYLabels{
+			XYs:    plotter.XYs{point},
+			Labels: []string{labels[i]},
+		})
+		if err != nil {
+			return err
+		}
+		offset := vg.Length(4)
+		label.Offset = vg.Point{X: offset, Y: offset}
+		label.TextStyle[0].XAlign, label.TextStyle[0].YAlign = text.XLeft, text.YBottom
+		if point.X < cx {
+			label.Offset.X = -offset
+			label.TextStyle[0].XAlign = text.XRight
+		}
+		if point.Y < cy {
+			label.Offset.Y = -offset
+			label.TextStyle[0].YAlign = text.YTop
+		}
+		p.Add(label)
+	}
+
+	return p.Save(8*vg.Inch, 8*vg.Inch, fmt.Sprintf("%s.png", name))
+}
+
+// NearestNeighborPCA uses nearest neighbor on the euclidean distances between
+// the rows of ranks projected onto two principal components, the tours are
+// costed with dist and the best is returned
+func NearestNeighborPCA(ranks *mat.Dense, dist []float64, size int) Tour {
+	proj, err := project(ranks)
+	if err != nil {
+		panic(err)
+	}
+	distances := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			a, b := proj.At(i, 0)-proj.At(j, 0), proj.At(i, 1)-proj.At(j, 1)
+			distances[i*size+j] = math.Sqrt(a*a + b*b)
+		}
+	}
+	best := Tour{Cost: math.MaxFloat64}
+	for offset := 0; offset < size; offset++ {
+		route := nearestNeighbor(distances, size, offset).Route
+		if cost := TourCost(dist, size, route); cost < best.Cost {
+			best.Cost, best.Route = cost, route
+		}
+	}
+	return best
+}
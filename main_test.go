@@ -0,0 +1,364 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// TestMain runs main instead of the tests when SALESMAN_MAIN is set, so the
+// tests can run the command by executing the test binary
+func TestMain(m *testing.M) {
+	if os.Getenv("SALESMAN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestQuiet(t *testing.T) {
+	command := exec.Command(os.Args[0], "-quiet", "-compare")
+	command.Env = append(os.Environ(), "SALESMAN_MAIN=1")
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	stdout, err := command.Output()
+	if err != nil {
+		t.Fatalf("%v: %s", err, stderr.String())
+	}
+	if lines := bytes.Count(stdout, []byte("\n")); lines != 1 {
+		t.Fatalf("Expected 1 line, got %d: %s", lines, stdout)
+	}
+	var tour tourJSON
+	if err := json.Unmarshal(stdout, &tour); err != nil {
+		t.Fatalf("Expected json, got %s: %v", stdout, err)
+	}
+	if err := ValidateTour(tour.Route, Size); err != nil {
+		t.Error(err)
+	}
+
+	command = exec.Command(os.Args[0], "-quiet", "-input", filepath.Join(t.TempDir(), "missing.json"))
+	command.Env = append(os.Environ(), "SALESMAN_MAIN=1")
+	stdout, err = command.Output()
+	if exit, ok := err.(*exec.ExitError); !ok || exit.ExitCode() != 1 {
+		t.Errorf("Expected exit code 1, got %v", err)
+	}
+	if len(stdout) != 0 {
+		t.Errorf("Expected no output, got %s", stdout)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		tour string
+		exit int
+	}{
+		{`{"cost": 97, "route": [0, 1, 2, 3, 0]}`, 0},
+		{`{"cost": 100, "route": [0, 1, 2, 3, 0]}`, 1},
+		{`{"cost": 97, "route": [0, 1, 2, 0]}`, 1},
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "tour.json")
+		if err := os.WriteFile(path, []byte(test.tour), 0644); err != nil {
+			t.Fatal(err)
+		}
+		command := exec.Command(os.Args[0], "-verify", path)
+		command.Env = append(os.Environ(), "SALESMAN_MAIN=1")
+		err := command.Run()
+		exit := 0
+		if err, ok := err.(*exec.ExitError); ok {
+			exit = err.ExitCode()
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if exit != test.exit {
+			t.Errorf("Expected exit code %d for %s, got %d", test.exit, test.tour, exit)
+		}
+	}
+}
+
+func TestSeedFromInstanceProcesses(t *testing.T) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 8)
+	instance, err := json.Marshal(Instance{Name: "random8", Size: 8, Dist: dist})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "random8.json")
+	if err := os.WriteFile(path, instance, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// the last run uses the default seed of 1
+	outputs := make([][]byte, 3)
+	for i := range outputs {
+		args := []string{"-seed-from-instance", "-input", path}
+		if i == 2 {
+			args = args[1:]
+		}
+		command := exec.Command(os.Args[0], args...)
+		command.Env = append(os.Environ(), "SALESMAN_MAIN=1")
+		outputs[i], err = command.Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		t.Errorf("Expected the same output, got\n%s\nand\n%s", outputs[0], outputs[1])
+	}
+	if bytes.Equal(outputs[0], outputs[2]) {
+		t.Errorf("Expected the seeds derived from the instance to change the output\n%s", outputs[0])
+	}
+}
+
+func TestReductionLabeled(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranks := mat.NewDense(Size, Size, nil)
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			ranks.Set(i, j, rng.Float64())
+		}
+	}
+	name := filepath.Join(t.TempDir(), "labeled")
+	if err := ReductionLabeled(name, ranks, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(name + ".png"); err != nil {
+		t.Error(err)
+	}
+	if err := ReductionLabeled(name, ranks, []string{"A"}); err == nil {
+		t.Error("Expected an error for the wrong number of labels")
+	}
+}
+
+func TestNearestNeighborPCA(t *testing.T) {
+	vectors, _, _ := Eigen(canonical)
+	ranks := mat.NewDense(Size, Size, nil)
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			ranks.Set(i, j, real(vectors.At(i, j)))
+		}
+	}
+	tour := NearestNeighborPCA(ranks, canonical, Size)
+	if err := ValidateTour(tour.Route, Size); err != nil {
+		t.Fatal(err)
+	}
+	if cost := TourCost(canonical, Size, tour.Route); cost != tour.Cost {
+		t.Errorf("Expected cost %f, got %f", cost, tour.Cost)
+	}
+}
+
+func TestNeuralMaxGradNorm(t *testing.T) {
+	spiky, size := MustNewDenseMatrix([][]float64{
+		{0, 1e6, 1, 1e6},
+		{1e6, 0, 1e6, 1},
+		{1, 1e6, 0, 1e6},
+		{1e6, 1, 1e6, 0},
+	})
+	for _, norm := range []float64{0, DefaultMaxGradNorm} {
+		cost, route := NeuralWithOptions(spiky, NeuralOptions{MaxGradNorm: norm, Seed: 1})
+		if err := VerifyTour(spiky, size, Tour{Cost: cost, Route: route}); err != nil {
+			t.Errorf("Expected a valid tour with a max gradient norm of %f: %v", norm, err)
+		}
+	}
+	// from a zero embedding the sigmoid isn't saturated and the gradient of
+	// the spiky distances is large, the first step moves the embedding by at
+	// most the learning rate of .3 times the max gradient norm
+	step := func(norm float64) float64 {
+		w, _, _ := neuralTrainFrom(spiky, 4, 1, make([]float64, 4*Size*Size), NeuralOptions{MaxGradNorm: norm})
+		sum := 0.0
+		for _, weight := range w {
+			sum += weight * weight
+		}
+		return math.Sqrt(sum)
+	}
+	if clipped := step(DefaultMaxGradNorm); clipped > .3*DefaultMaxGradNorm+1e-9 {
+		t.Errorf("Expected the clipped step to be at most %f, got %f", .3*DefaultMaxGradNorm, clipped)
+	}
+	if unclipped := step(0); unclipped <= .3*DefaultMaxGradNorm {
+		t.Errorf("Expected a max gradient norm of zero to leave the gradient unclipped, got a step of %f", unclipped)
+	}
+}
+
+func TestNeuralWeightInit(t *testing.T) {
+	cols, rows := Size, 4*Size
+	weights := neuralWeights(cols, rows, WeightInitOrthogonal, rand.New(rand.NewSource(1)))
+	for j := 0; j < cols; j++ {
+		for k := 0; k < cols; k++ {
+			dot := 0.0
+			for i := 0; i < rows; i++ {
+				dot += weights[i*cols+j] * weights[i*cols+k]
+			}
+			expected := 0.0
+			if j == k {
+				expected = 1
+			}
+			if math.Abs(dot-expected) > 1e-10 {
+				t.Errorf("Expected columns %d and %d to have a dot product of %f, got %g", j, k, expected, dot)
+			}
+		}
+	}
+	for _, method := range []string{WeightInitHe, WeightInitXavier, WeightInitOrthogonal, WeightInitZero} {
+		cost, route := NeuralWithOptions(canonical, NeuralOptions{MaxGradNorm: DefaultMaxGradNorm, WeightInit: method, Seed: 1})
+		if err := VerifyTour(canonical, Size, Tour{Cost: cost, Route: route}); err != nil {
+			t.Errorf("Expected a valid tour with %s initialization: %v", method, err)
+		}
+	}
+}
+
+func TestNeuralEnsemble(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	better, ensembles, singles := 0, 0.0, 0.0
+	for i := 0; i < 10; i++ {
+		dist := randomInstance(rng, Size)
+		opts := NeuralOptions{MaxGradNorm: DefaultMaxGradNorm}
+		ensemble := NeuralEnsemble(dist, Size, 4, opts)
+		if err := VerifyTour(dist, Size, ensemble); err != nil {
+			t.Fatal(err)
+		}
+		// the members of the ensemble have the seeds 1 to 4, the single
+		// networks have other seeds
+		mean := 0.0
+		for seed := int64(5); seed <= 8; seed++ {
+			opts.Seed = seed
+			single, _ := NeuralWithOptions(dist, opts)
+			mean += single / 4
+		}
+		if ensemble.Cost <= mean {
+			better++
+		}
+		ensembles += ensemble.Cost
+		singles += mean
+	}
+	if better < 8 {
+		t.Errorf("Expected the ensemble to be at least as good as the mean single network on 8 of 10 instances, got %d", better)
+	}
+	if ensembles >= singles {
+		t.Errorf("Expected the ensemble to cost less than the mean single network in total, got %f and %f", ensembles, singles)
+	}
+}
+
+func TestEmbeddingCorrelation(t *testing.T) {
+	if r := Spearman([]float64{1, 2, 3, 4}, []float64{10, 20, 40, 80}); r != 1 {
+		t.Errorf("Expected a monotone relationship to have a correlation of 1, got %f", r)
+	}
+	if r := Spearman([]float64{1, 2, 3, 4}, []float64{8, 4, 2, 1}); r != -1 {
+		t.Errorf("Expected a decreasing relationship to have a correlation of -1, got %f", r)
+	}
+	if r := ranks([]float64{3, 1, 3, 2}); !reflect.DeepEqual(r, []float64{3.5, 1, 3.5, 2}) {
+		t.Errorf("Expected ties to share their average rank, got %v", r)
+	}
+
+	// cities on a line embedded at their positions
+	positions := []float64{0, 1, 3, 7}
+	line := make([]float64, Size*Size)
+	w := make([]float64, Size*Size)
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			line[i*Size+j] = math.Abs(positions[i] - positions[j])
+		}
+		w[i] = positions[i]
+	}
+	if r := EmbeddingCorrelation(line, w, 1); math.Abs(r-1) > 1e-9 {
+		t.Errorf("Expected an embedding of the positions to have a correlation of 1, got %f", r)
+	}
+
+	for seed := int64(1); seed <= 4; seed++ {
+		w, _, _ := neuralTrain(canonical, 4, 1024, NeuralOptions{MaxGradNorm: DefaultMaxGradNorm, Seed: seed})
+		r := EmbeddingCorrelation(canonical, w, 4)
+		if math.IsNaN(r) || r < -1 || r > 1 {
+			t.Errorf("Expected a correlation between -1 and 1 with seed %d, got %f", seed, r)
+		}
+	}
+}
+
+func TestNeuralSolverReset(t *testing.T) {
+	solver := NewNeuralSolver(NeuralOptions{MaxGradNorm: DefaultMaxGradNorm, Seed: 1})
+	first := append([]float64{}, solver.weights...)
+	solver.Reset(2)
+	if reflect.DeepEqual(solver.weights, first) {
+		t.Error("Expected different weights for a different seed")
+	}
+	solver.Reset(1)
+	if !reflect.DeepEqual(solver.weights, first) {
+		t.Error("Expected the same weights for the same seed")
+	}
+	if err := solver.Train(canonical, Size); err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(solver.weights, first) {
+		t.Error("Expected training to change the weights")
+	}
+	if err := VerifyTour(canonical, Size, solver.Solve(canonical, Size)); err != nil {
+		t.Error(err)
+	}
+	if err := solver.Train(make([]float64, 25), 5); err == nil {
+		t.Error("Expected an error for an instance without Size cities")
+	}
+}
+
+func TestNeuralSolverEmbed(t *testing.T) {
+	solver := NewNeuralSolver(NeuralOptions{MaxGradNorm: DefaultMaxGradNorm, Seed: 1})
+	if err := solver.Train(canonical, Size); err != nil {
+		t.Fatal(err)
+	}
+	embedding := solver.EmbeddingMatrix()
+	for i := 0; i < Size; i++ {
+		vector := solver.Embed(i)
+		if len(vector) != neuralScale*Size {
+			t.Fatalf("Expected an embedding of length %d, got %d", neuralScale*Size, len(vector))
+		}
+		if !reflect.DeepEqual(vector, mat.Row(nil, i, embedding)) {
+			t.Errorf("Expected row %d of the embedding matrix to be the embedding of city %d", i, i)
+		}
+		distances := embeddingDistances(solver.weights, neuralScale)
+		for j := 0; j < Size; j++ {
+			if d := floats.Distance(vector, solver.Embed(j), 2); math.Abs(d-distances[i*Size+j]) > 1e-9 {
+				t.Errorf("Expected the distance between %d and %d to be %f, got %f", i, j, distances[i*Size+j], d)
+			}
+		}
+	}
+	solver.Embed(0)[0] = math.NaN()
+	if math.IsNaN(solver.Embed(0)[0]) {
+		t.Error("Expected Embed to return a copy")
+	}
+}
+
+func TestOutputMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cities.csv")
+	input := ", A, B, C, D, population\nA, 0, 20, 42, 35, 100\nB, 20, 0, 30, 34, 2500\nC, 42, 30, 0, 12, 40\nD, 35, 34, 12, 0, 7\n"
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	command := exec.Command(os.Args[0], "-quiet", "-input", path, "-output", "json")
+	command.Env = append(os.Environ(), "SALESMAN_MAIN=1")
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	stdout, err := command.Output()
+	if err != nil {
+		t.Fatalf("%v: %s", err, stderr.String())
+	}
+	var tour tourJSON
+	if err := json.Unmarshal(stdout, &tour); err != nil {
+		t.Fatalf("Expected json, got %s: %v", stdout, err)
+	}
+	populations := map[string]float64{"A": 100, "B": 2500, "C": 40, "D": 7}
+	if len(tour.Cities) != 4 {
+		t.Fatalf("Expected 4 cities, got %s", stdout)
+	}
+	for i, city := range tour.Cities {
+		if city.ID != tour.Route[i] || city.Metadata["population"] != populations[city.Label] {
+			t.Errorf("Expected city %d of the route with its population, got %+v", tour.Route[i], city)
+		}
+	}
+}
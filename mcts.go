@@ -0,0 +1,137 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// MCTSOptions are the options for Monte Carlo tree search
+type MCTSOptions struct {
+	// Iterations is the number of selection, expansion, rollout and
+	// backpropagation rounds
+	Iterations int
+	// ExplorationConst scales the exploration term of UCB1
+	ExplorationConst float64
+	// Seed seeds the random number generator
+	Seed int64
+}
+
+// mctsNode is a partial tour in the search tree
+type mctsNode struct {
+	city     int
+	parent   *mctsNode
+	children []*mctsNode
+	// untried are the cities that haven't been expanded from this node
+	untried []int
+	visits  int
+	// reward is the sum of the rewards of the rollouts through this node
+	reward float64
+}
+
+// ucb1 is the upper confidence bound of the node
+func (n *mctsNode) ucb1(exploration float64) float64 {
+	return n.reward/float64(n.visits) +
+		exploration*math.Sqrt(math.Log(float64(n.parent.visits))/float64(n.visits))
+}
+
+// rollout completes the partial route, choosing the next city with a
+// probability proportional to the inverse of the cost of going there so that
+// the rollouts are guided by nearest neighbor
+func rollout(dist []float64, size int, route []int, rng *rand.Rand) []int {
+	visited := make([]bool, size)
+	for _, city := range route {
+		visited[city] = true
+	}
+	weights := make([]float64, size)
+	for len(route) < size {
+		last, total := route[len(route)-1], 0.0
+		for j := 0; j < size; j++ {
+			weights[j] = 0
+			if visited[j] {
+				continue
+			}
+			weights[j] = 1 / math.Max(dist[last*size+j], 1e-9)
+			total += weights[j]
+		}
+		spin, next := rng.Float64()*total, -1
+		for j, weight := range weights {
+			if weight == 0 {
+				continue
+			}
+			next = j
+			if spin -= weight; spin <= 0 {
+				break
+			}
+		}
+		visited[next] = true
+		route = append(route, next)
+	}
+	return append(route, route[0])
+}
+
+// MonteCarloTreeSearch uses Monte Carlo tree search to solve the traveling
+// salesman problem. Each node of the tree is a partial tour starting at city
+// 0, the rollout rewards are the nearest neighbor cost over the rollout cost
+// so they are around 1 regardless of the scale of the costs.
+func MonteCarloTreeSearch(dist []float64, size int, opts MCTSOptions) Tour {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	best := nearestNeighbor(dist, size, 0)
+	if size < 3 {
+		return best
+	}
+	baseline := best.Cost
+	if baseline <= 0 {
+		baseline = 1
+	}
+	children := func(route []int) []int {
+		visited := make([]bool, size)
+		for _, city := range route {
+			visited[city] = true
+		}
+		untried := make([]int, 0, size)
+		for j := 0; j < size; j++ {
+			if !visited[j] {
+				untried = append(untried, j)
+			}
+		}
+		return untried
+	}
+	root := &mctsNode{city: 0, untried: children([]int{0})}
+	for i := 0; i < opts.Iterations; i++ {
+		node, route := root, []int{0}
+		for len(node.untried) == 0 && len(node.children) > 0 {
+			bestScore, next := math.Inf(-1), node.children[0]
+			for _, child := range node.children {
+				if score := child.ucb1(opts.ExplorationConst); score > bestScore {
+					bestScore, next = score, child
+				}
+			}
+			node = next
+			route = append(route, node.city)
+		}
+		if len(node.untried) > 0 {
+			k := rng.Intn(len(node.untried))
+			city := node.untried[k]
+			node.untried = append(node.untried[:k], node.untried[k+1:]...)
+			route = append(route, city)
+			child := &mctsNode{city: city, parent: node, untried: children(route)}
+			node.children = append(node.children, child)
+			node = child
+		}
+		complete := rollout(dist, size, append([]int{}, route...), rng)
+		cost := TourCost(dist, size, complete)
+		if cost < best.Cost {
+			best = Tour{Cost: cost, Route: complete}
+		}
+		reward := baseline / math.Max(cost, 1e-9)
+		for ; node != nil; node = node.parent {
+			node.visits++
+			node.reward += reward
+		}
+	}
+	return best
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMonteCarloTreeSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		dist := randomInstance(rng, 6)
+		optimal, _ := Search(dist)
+		tour := MonteCarloTreeSearch(dist, 6, MCTSOptions{
+			Iterations:       2000,
+			ExplorationConst: 1,
+			Seed:             1,
+		})
+		if err := ValidateTour(tour.Route, 6); err != nil {
+			t.Fatal(err)
+		}
+		if c := TourCost(dist, 6, tour.Route); c != tour.Cost {
+			t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+		}
+		if tour.Cost != optimal {
+			t.Errorf("Expected optimal cost %f, got %f", optimal, tour.Cost)
+		}
+	}
+}
+
+func BenchmarkMonteCarloTreeSearch(b *testing.B) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 8)
+	for i := 0; i < b.N; i++ {
+		MonteCarloTreeSearch(dist, 8, MCTSOptions{Iterations: 1000, ExplorationConst: 1, Seed: 1})
+	}
+}
+
+func BenchmarkSearch8(b *testing.B) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 8)
+	for i := 0; i < b.N; i++ {
+		Search(dist)
+	}
+}
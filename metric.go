@@ -0,0 +1,126 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// SymmetrizeMin takes the shorter of the two directions
+	SymmetrizeMin = "min"
+	// SymmetrizeMax takes the longer of the two directions
+	SymmetrizeMax = "max"
+	// SymmetrizeAvg takes the average of the two directions
+	SymmetrizeAvg = "avg"
+)
+
+// Violation is a triple of cities that violates the triangle inequality
+type Violation struct {
+	I, J, K int
+	// Excess is how much dist[i][j] exceeds dist[i][k] + dist[k][j]
+	Excess float64
+}
+
+// TriangleInequalityCheck lists the triples of distinct cities where going
+// from i to j directly costs more than going through k
+func TriangleInequalityCheck(dist []float64, size int) []Violation {
+	var violations []Violation
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i == j {
+				continue
+			}
+			for k := 0; k < size; k++ {
+				if k == i || k == j {
+					continue
+				}
+				if excess := dist[i*size+j] - dist[i*size+k] - dist[k*size+j]; excess > 1e-9 {
+					violations = append(violations, Violation{I: i, J: j, K: k, Excess: excess})
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// warnNonMetric prints a warning if the instance violates the triangle
+// inequality that the approximation guarantee of the solver depends on
+func warnNonMetric(solver string, dist []float64, size int) {
+	violations := TriangleInequalityCheck(dist, size)
+	if len(violations) == 0 {
+		return
+	}
+	worst := violations[0]
+	for _, violation := range violations[1:] {
+		if violation.Excess > worst.Excess {
+			worst = violation
+		}
+	}
+	fmt.Printf("warning: %s needs a metric instance, %d triangle inequality violations, the worst is %d to %d through %d by %f\n",
+		solver, len(violations), worst.I, worst.J, worst.K, worst.Excess)
+}
+
+// SymmetryCheck returns true if the distance from i to j is the distance from
+// j to i for all of the cities
+func SymmetryCheck(dist []float64, size int) bool {
+	for i := 0; i < size; i++ {
+		for j := i + 1; j < size; j++ {
+			if dist[i*size+j] != dist[j*size+i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Symmetrize returns a symmetric copy of the distances where both directions
+// between two cities are replaced with their min, max or avg
+func Symmetrize(dist []float64, size int, method string) []float64 {
+	var combine func(a, b float64) float64
+	switch method {
+	case SymmetrizeMin:
+		combine = math.Min
+	case SymmetrizeMax:
+		combine = math.Max
+	case SymmetrizeAvg:
+		combine = func(a, b float64) float64 {
+			return (a + b) / 2
+		}
+	default:
+		panic(fmt.Sprintf("unknown symmetrize method %q", method))
+	}
+	symmetric := make([]float64, len(dist))
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			symmetric[i*size+j] = combine(dist[i*size+j], dist[j*size+i])
+		}
+	}
+	return symmetric
+}
+
+// HasNegativeCycle uses Bellman-Ford on the complete graph of the cities to
+// find a cycle with a negative total distance. Negative distances are allowed
+// as long as there is no negative cycle.
+func HasNegativeCycle(dist []float64, size int) bool {
+	// starting every city at zero is the same as a source with a zero
+	// distance edge to every city
+	distance := make([]float64, size)
+	for iteration := 0; iteration < size; iteration++ {
+		relaxed := false
+		for i := 0; i < size; i++ {
+			for j := 0; j < size; j++ {
+				if d := distance[i] + dist[i*size+j]; d < distance[j] {
+					distance[j], relaxed = d, true
+				}
+			}
+		}
+		if !relaxed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestTriangleInequalityCheck(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	if violations := TriangleInequalityCheck(euclideanInstance(rng, 8), 8); len(violations) != 0 {
+		t.Errorf("Expected a euclidean instance to be metric, got %v", violations)
+	}
+
+	// going from 0 to 1 directly costs 10 but only 2 through 2
+	dist := []float64{
+		0, 10, 1,
+		10, 0, 1,
+		1, 1, 0,
+	}
+	violations := TriangleInequalityCheck(dist, 3)
+	expected := []Violation{{I: 0, J: 1, K: 2, Excess: 8}, {I: 1, J: 0, K: 2, Excess: 8}}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, violations)
+	}
+	for i, violation := range violations {
+		if violation != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], violation)
+		}
+	}
+}
+
+func TestSymmetrize(t *testing.T) {
+	dist := []float64{
+		0, 1, 4,
+		3, 0, 2,
+		4, 6, 0,
+	}
+	if SymmetryCheck(dist, 3) {
+		t.Error("Expected the distances to be asymmetric")
+	}
+	tests := []struct {
+		method   string
+		expected []float64
+	}{
+		{SymmetrizeMin, []float64{0, 1, 4, 1, 0, 2, 4, 2, 0}},
+		{SymmetrizeMax, []float64{0, 3, 4, 3, 0, 6, 4, 6, 0}},
+		{SymmetrizeAvg, []float64{0, 2, 4, 2, 0, 4, 4, 4, 0}},
+	}
+	for _, test := range tests {
+		symmetric := Symmetrize(dist, 3, test.method)
+		if !reflect.DeepEqual(symmetric, test.expected) {
+			t.Errorf("Expected %s to be %v, got %v", test.method, test.expected, symmetric)
+		}
+		if !SymmetryCheck(symmetric, 3) {
+			t.Errorf("Expected %s to be symmetric", test.method)
+		}
+	}
+}
+
+func TestHasNegativeCycle(t *testing.T) {
+	dist, size := MustNewDenseMatrix([][]float64{
+		{0, -5, 42, 35},
+		{20, 0, 30, 34},
+		{42, 30, 0, 12},
+		{35, 34, 12, 0},
+	})
+	if HasNegativeCycle(dist, size) {
+		t.Error("Expected no negative cycle")
+	}
+	cost, route := Search(dist)
+	used := false
+	for i := 0; i < len(route)-1; i++ {
+		used = used || route[i] == 0 && route[i+1] == 1
+	}
+	if !used {
+		t.Errorf("Expected the optimal tour to use the negative edge, got %v", route)
+	}
+	if cost != TourCost(dist, size, route) {
+		t.Errorf("Expected cost %f, got %f", TourCost(dist, size, route), cost)
+	}
+
+	dist[1*size+0] = 4
+	if !HasNegativeCycle(dist, size) {
+		t.Error("Expected the cycle 0 1 0 to be negative")
+	}
+	if _, err := ReadInstanceFromReader(bytes.NewBufferString("0, -5\n4, 0\n"), "csv"); err == nil {
+		t.Error("Expected an error for a negative cycle")
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// MOOptions are the options for the multi-objective traveling salesman
+// problem
+type MOOptions struct {
+	// Steps is the number of steps of the grid of weights between 0 and 1,
+	// zero defaults to 10 for the weights 0, 0.1, ..., 1
+	Steps int
+	// Iterations is the number of perturbations to try for each weight
+	Iterations int
+	// Seed seeds the random number generator
+	Seed int64
+}
+
+// MaxEdge is the cost of the most expensive edge of a closed route
+func MaxEdge(dist []float64, size int, route []int) float64 {
+	max := 0.0
+	for i := 1; i < len(route); i++ {
+		if d := dist[route[i-1]*size+route[i]]; d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// scalarizedCost weighs the cost of the route against its most expensive
+// edge, the edge is scaled by size so both objectives have the same range
+func scalarizedCost(dist []float64, size int, route []int, weight float64) float64 {
+	return weight*TourCost(dist, size, route) + (1-weight)*float64(size)*MaxEdge(dist, size, route)
+}
+
+// scalarizedTwoOpt improves the route with 2-opt moves on the scalarized cost
+// until no move improves it
+func scalarizedTwoOpt(dist []float64, size int, tour []int, weight float64) (float64, []int) {
+	route := append([]int{}, tour...)
+	cost := scalarizedCost(dist, size, route, weight)
+	improved := true
+	for improved {
+		improved = false
+		for i := 1; i < size-1; i++ {
+			for j := i + 1; j < size; j++ {
+				reverse(route, i, j)
+				if c := scalarizedCost(dist, size, route, weight); c < cost {
+					cost, improved = c, true
+					continue
+				}
+				reverse(route, i, j)
+			}
+		}
+	}
+	return cost, route
+}
+
+// MultiObjectiveTSP trades the total cost of a tour off against its most
+// expensive edge. Iterated local search minimizes the weighted sum of the two
+// for each weight of a grid and the non-dominated tours are returned sorted by
+// cost.
+func MultiObjectiveTSP(dist []float64, size int, opts MOOptions) []Tour {
+	steps := opts.Steps
+	if steps == 0 {
+		steps = 10
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+	start := nearestNeighbor(dist, size, 0).Route
+	tours := make([]Tour, 0, steps+1)
+	for step := 0; step <= steps; step++ {
+		weight := float64(step) / float64(steps)
+		cost, route := scalarizedTwoOpt(dist, size, start, weight)
+		for i := 0; i < opts.Iterations; i++ {
+			c, r := scalarizedTwoOpt(dist, size, DoubleBridge(route, rng), weight)
+			if c < cost {
+				cost, route = c, r
+			}
+		}
+		tours = append(tours, Tour{
+			Cost:    TourCost(dist, size, route),
+			Route:   route,
+			MaxEdge: MaxEdge(dist, size, route),
+		})
+	}
+	return ParetoFront(tours)
+}
+
+// ParetoFront returns the tours that no other tour dominates by cost and
+// max edge sorted by cost, only the first of tours with the same cost and max
+// edge is kept
+func ParetoFront(tours []Tour) []Tour {
+	front := make([]Tour, 0, len(tours))
+	for i, tour := range tours {
+		dominated := false
+		for j, other := range tours {
+			better := other.Cost < tour.Cost || other.MaxEdge < tour.MaxEdge
+			same := other.Cost == tour.Cost && other.MaxEdge == tour.MaxEdge
+			if other.Cost <= tour.Cost && other.MaxEdge <= tour.MaxEdge && (better || same && j < i) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, tour)
+		}
+	}
+	sort.SliceStable(front, func(i, j int) bool {
+		return front[i].Cost < front[j].Cost
+	})
+	return front
+}
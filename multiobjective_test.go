@@ -0,0 +1,57 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMultiObjectiveTSP(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 8; trial++ {
+		dist := randomInstance(rng, 10)
+		front := MultiObjectiveTSP(dist, 10, MOOptions{Iterations: 10, Seed: int64(trial)})
+		if len(front) == 0 {
+			t.Fatal("Expected at least one tour")
+		}
+		for i, tour := range front {
+			if err := VerifyTour(dist, 10, tour); err != nil {
+				t.Fatal(err)
+			}
+			if max := MaxEdge(dist, 10, tour.Route); max != tour.MaxEdge {
+				t.Errorf("Expected max edge %f, got %f", max, tour.MaxEdge)
+			}
+			if i > 0 && front[i-1].Cost > tour.Cost {
+				t.Errorf("Expected the tours to be sorted by cost, got %f before %f", front[i-1].Cost, tour.Cost)
+			}
+			for j, other := range front {
+				if i != j && other.Cost <= tour.Cost && other.MaxEdge <= tour.MaxEdge {
+					t.Errorf("Expected tour %v to not be dominated by %v", tour, other)
+				}
+			}
+		}
+	}
+}
+
+func TestParetoFront(t *testing.T) {
+	tours := []Tour{
+		{Cost: 10, MaxEdge: 5},
+		{Cost: 12, MaxEdge: 3},
+		{Cost: 11, MaxEdge: 6},
+		{Cost: 8, MaxEdge: 7},
+		{Cost: 10, MaxEdge: 5},
+	}
+	front := ParetoFront(tours)
+	expected := []Tour{tours[3], tours[0], tours[1]}
+	if len(front) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, front)
+	}
+	for i := range front {
+		if front[i].Cost != expected[i].Cost || front[i].MaxEdge != expected[i].MaxEdge {
+			t.Errorf("Expected %v, got %v", expected[i], front[i])
+		}
+	}
+}
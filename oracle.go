@@ -0,0 +1,41 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "fmt"
+
+// OracleTolerance is how far below optimal a tour cost can be from rounding
+const OracleTolerance = 1e-9
+
+// Oracle knows the optimal tour of an instance from Search
+type Oracle struct {
+	dist    []float64
+	size    int
+	optimal Tour
+}
+
+// NewOracle finds the optimal tour of the instance with Search
+func NewOracle(dist []float64, size int) *Oracle {
+	cost, route := Search(dist)
+	return &Oracle{
+		dist:    dist,
+		size:    size,
+		optimal: Tour{Cost: cost, Route: route},
+	}
+}
+
+// Optimal is the optimal tour
+func (o *Oracle) Optimal() Tour {
+	return o.optimal
+}
+
+// Verify returns an error if the tour costs less than the optimal tour, which
+// is a bug in the solver of the tour or in its cost
+func (o *Oracle) Verify(t Tour) error {
+	if t.Cost < o.optimal.Cost-OracleTolerance {
+		return fmt.Errorf("tour cost %f is below optimal %f", t.Cost, o.optimal.Cost)
+	}
+	return nil
+}
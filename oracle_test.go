@@ -0,0 +1,27 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestOracle(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 8)
+	oracle := NewOracle(dist, 8)
+	optimal := oracle.Optimal()
+	if err := oracle.Verify(optimal); err != nil {
+		t.Errorf("Expected the optimal tour to verify, got %v", err)
+	}
+	if err := oracle.Verify(nearestNeighbor(dist, 8, 0)); err != nil {
+		t.Errorf("Expected the nearest neighbor tour to verify, got %v", err)
+	}
+	optimal.Cost -= 1e-6
+	if err := oracle.Verify(optimal); err == nil {
+		t.Error("Expected an error for a tour below optimal")
+	}
+}
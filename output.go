@@ -0,0 +1,157 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// tourJSON is the json representation of a tour
+type tourJSON struct {
+	Cost  float64 `json:"cost"`
+	Route []int   `json:"route"`
+	// Cities are the cities of the route with their labels and metadata,
+	// see WriteInstanceTourJSON
+	Cities []cityJSON `json:"cities,omitempty"`
+}
+
+// cityJSON is the json representation of a city of an instance
+type cityJSON struct {
+	ID       int                    `json:"id"`
+	Label    string                 `json:"label"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ReadTourJSON reads a tour written by WriteTourJSON
+func ReadTourJSON(r io.Reader) (Tour, error) {
+	var t tourJSON
+	err := json.NewDecoder(r).Decode(&t)
+	if err != nil {
+		return Tour{}, err
+	}
+	return Tour{Cost: t.Cost, Route: t.Route}, nil
+}
+
+// WriteTourText writes the cost and the route of the tour on one line
+func WriteTourText(w io.Writer, t Tour) error {
+	_, err := fmt.Fprintln(w, t.Cost, t.Route)
+	return err
+}
+
+// WriteTourLabeled writes the cost and the route of the tour on one line with
+// the cities named by the labels, such as 97 A → B → C → D → A
+func WriteTourLabeled(w io.Writer, t Tour, labels CityLabels) error {
+	_, err := fmt.Fprintln(w, t.Cost, labels.Route(t.Route))
+	return err
+}
+
+// WriteTourJSON writes the tour as a json object with a cost and a route
+func WriteTourJSON(w io.Writer, t Tour) error {
+	return json.NewEncoder(w).Encode(tourJSON{Cost: t.Cost, Route: t.Route})
+}
+
+// WriteInstanceTourJSON writes the tour of the instance like WriteTourJSON
+// with the cities of the route in order, without the return to the first
+// city. Each city has its id, its label and its metadata, so they don't have
+// to be joined from the instance.
+func WriteInstanceTourJSON(w io.Writer, t Tour, instance *Instance) error {
+	cities := make([]cityJSON, 0, len(t.Route))
+	for i, city := range t.Route {
+		if i == len(t.Route)-1 && i > 0 && city == t.Route[0] {
+			break
+		}
+		c := cityJSON{ID: city, Label: instance.Labels.Label(city)}
+		if city >= 0 && city < len(instance.Metadata) {
+			c.Metadata = instance.Metadata[city]
+		}
+		cities = append(cities, c)
+	}
+	return json.NewEncoder(w).Encode(tourJSON{Cost: t.Cost, Route: t.Route, Cities: cities})
+}
+
+// WriteTourCSV writes the tour as csv with a cost and a route column, the
+// cities of the route are separated by spaces
+func WriteTourCSV(w io.Writer, t Tour) error {
+	route := make([]string, len(t.Route))
+	for i, city := range t.Route {
+		route[i] = strconv.Itoa(city)
+	}
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"cost", "route"})
+	if err != nil {
+		return err
+	}
+	err = writer.Write([]string{strconv.FormatFloat(t.Cost, 'g', -1, 64), strings.Join(route, " ")})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteDOT writes the distances as a Graphviz digraph with the edges of the
+// tour in blue and the other edges in grey, the nodes are named by the labels
+// if there are any. Render it with dot -Tpng tour.dot -o tour.png.
+func WriteDOT(w io.Writer, dist []float64, size int, tour []int, labels []string) error {
+	if err := ValidateTour(tour, size); err != nil {
+		return err
+	}
+	if labels != nil && len(labels) != size {
+		return fmt.Errorf("expected %d labels, got %d", size, len(labels))
+	}
+	next := make([]int, size)
+	for i := 0; i < size; i++ {
+		next[tour[i]] = tour[i+1]
+	}
+	_, err := fmt.Fprintln(w, "digraph tour {")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < size; i++ {
+		label := strconv.Itoa(i)
+		if labels != nil {
+			label = labels[i]
+		}
+		_, err = fmt.Fprintf(w, "\t%d [label=%s];\n", i, strconv.Quote(label))
+		if err != nil {
+			return err
+		}
+	}
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i == j {
+				continue
+			}
+			color := "grey"
+			if next[i] == j {
+				color = "blue"
+			}
+			_, err = fmt.Fprintf(w, "\t%d -> %d [label=\"%g\", color=%s];\n", i, j, dist[i*size+j], color)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	_, err = fmt.Fprintln(w, "}")
+	return err
+}
+
+// WriteTour writes the tour in the format, one of text, json or csv
+func WriteTour(w io.Writer, t Tour, format string) error {
+	switch format {
+	case "text":
+		return WriteTourText(w, t)
+	case "json":
+		return WriteTourJSON(w, t)
+	case "csv":
+		return WriteTourCSV(w, t)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
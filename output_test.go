@@ -0,0 +1,132 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteTour(t *testing.T) {
+	tour := Tour{Cost: 97, Route: []int{0, 1, 2, 3, 0}, Iterations: 8}
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"text", "97 [0 1 2 3 0]\n"},
+		{"json", `{"cost":97,"route":[0,1,2,3,0]}` + "\n"},
+		{"csv", "cost,route\n97,0 1 2 3 0\n"},
+	}
+	for _, test := range tests {
+		var buffer bytes.Buffer
+		if err := WriteTour(&buffer, tour, test.format); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != test.expected {
+			t.Errorf("Expected %s output %q, got %q", test.format, test.expected, buffer.String())
+		}
+	}
+	if err := WriteTour(&bytes.Buffer{}, tour, "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+	if err := WriteTourJSON(failingWriter{}, tour); err == nil {
+		t.Error("Expected the write error")
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteTourLabeled(t *testing.T) {
+	var buffer bytes.Buffer
+	tour := Tour{Cost: 97, Route: []int{0, 2, 3, 1, 0}}
+	err := WriteTourLabeled(&buffer, tour, CityLabels{"A", "B", "C", "D"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "97 A → C → D → B → A\n"; buffer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buffer.String())
+	}
+	buffer.Reset()
+	err = WriteTourLabeled(&buffer, tour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "97 0 → 2 → 3 → 1 → 0\n"; buffer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buffer.String())
+	}
+}
+
+func TestWriteInstanceTourJSON(t *testing.T) {
+	input := ", A, B, C, population\nA, 0, 1, 2, 100\nB, 1, 0, 3, 2500\nC, 2, 3, 0, 40\n"
+	instance, err := ReadInstanceFromReader(strings.NewReader(input), "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	tour := Tour{Cost: 6, Route: []int{0, 2, 1, 0}}
+	if err := WriteInstanceTourJSON(&buffer, tour, instance); err != nil {
+		t.Fatal(err)
+	}
+	var decoded tourJSON
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	expected := []cityJSON{
+		{ID: 0, Label: "A", Metadata: map[string]interface{}{"population": 100.0}},
+		{ID: 2, Label: "C", Metadata: map[string]interface{}{"population": 40.0}},
+		{ID: 1, Label: "B", Metadata: map[string]interface{}{"population": 2500.0}},
+	}
+	if !reflect.DeepEqual(decoded.Cities, expected) {
+		t.Errorf("Expected %v, got %v", expected, decoded.Cities)
+	}
+	read, err := ReadTourJSON(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Cost != tour.Cost || !reflect.DeepEqual(read.Route, tour.Route) {
+		t.Errorf("Expected %v, got %v", tour, read)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteDOT(&buffer, canonical, Size, []int{0, 2, 3, 1, 0}, []string{"A", "B", "C", "D"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dot := buffer.String()
+	if !strings.HasPrefix(dot, "digraph") {
+		t.Errorf("Expected a digraph, got %s", dot)
+	}
+	for _, node := range []string{`0 [label="A"]`, `1 [label="B"]`, `2 [label="C"]`, `3 [label="D"]`} {
+		if !strings.Contains(dot, node) {
+			t.Errorf("Expected the node %s, got %s", node, dot)
+		}
+	}
+	if blue := strings.Count(dot, "color=blue"); blue != Size {
+		t.Errorf("Expected %d tour edges, got %d", Size, blue)
+	}
+	if grey := strings.Count(dot, "color=grey"); grey != Size*(Size-1)-Size {
+		t.Errorf("Expected %d other edges, got %d", Size*(Size-1)-Size, grey)
+	}
+	if !strings.Contains(dot, `2 -> 3 [label="12", color=blue]`) {
+		t.Errorf("Expected the tour edge from 2 to 3, got %s", dot)
+	}
+	if err := WriteDOT(&buffer, canonical, Size, []int{0, 1, 2, 0}, nil); err == nil {
+		t.Error("Expected an error for an invalid tour")
+	}
+	if err := WriteDOT(&buffer, canonical, Size, []int{0, 1, 2, 3, 0}, []string{"A"}); err == nil {
+		t.Error("Expected an error for the wrong number of labels")
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strconv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PlotParetoFront plots the trade-off between the cost and the max edge of the
+// tours, such as the front found by MultiObjectiveTSP, with each point labeled
+// by the index of its tour
+func PlotParetoFront(tours []Tour, dist []float64, size int, path string) error {
+	points := make(plotter.XYs, len(tours))
+	labels := make([]string, len(tours))
+	for i, tour := range tours {
+		points[i].X = TourCost(dist, size, tour.Route)
+		points[i].Y = MaxEdge(dist, size, tour.Route)
+		labels[i] = strconv.Itoa(i)
+	}
+
+	p := plot.New()
+
+	p.Title.Text = "pareto front"
+	p.X.Label.Text = "cost"
+	p.Y.Label.Text = "max edge"
+
+	line, scatter, err := plotter.NewLinePoints(points)
+	if err != nil {
+		return err
+	}
+	scatter.GlyphStyle.Radius = vg.Length(3)
+	scatter.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(line, scatter)
+
+	label, err := plotter.NewLabels(plotter.XYLabels{
+		XYs:    points,
+		Labels: labels,
+	})
+	if err != nil {
+		return err
+	}
+	label.Offset = vg.Point{X: vg.Length(4), Y: vg.Length(4)}
+	p.Add(label)
+
+	return p.Save(8*vg.Inch, 8*vg.Inch, path)
+}
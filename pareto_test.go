@@ -0,0 +1,27 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlotParetoFront(t *testing.T) {
+	dist := euclideanInstance(rand.New(rand.NewSource(4)), 6)
+	front := MultiObjectiveTSP(dist, 6, MOOptions{Iterations: 10, Seed: 1})
+	if len(front) < 3 {
+		t.Fatalf("Expected at least 3 non-dominated tours, got %d", len(front))
+	}
+	path := filepath.Join(t.TempDir(), "pareto.png")
+	if err := PlotParetoFront(front, dist, 6, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error(err)
+	}
+}
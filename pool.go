@@ -0,0 +1,202 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SolutionPool keeps a portfolio of good and structurally different tours
+type SolutionPool struct {
+	// MaxSize is the maximum number of tours in the pool, zero or less is
+	// unbounded
+	MaxSize int
+	// MinDistance is the edge distance at which a tour is different enough
+	// to be kept even if it isn't one of the best
+	MinDistance int
+	tours       []Tour
+}
+
+// closest is the edge distance from t to the closest tour in the pool,
+// skipping the tour at index skip
+func (p *SolutionPool) closest(t Tour, skip int) int {
+	min := len(t.Route)
+	for i, tour := range p.tours {
+		if i == skip {
+			continue
+		}
+		if d := TourEdgeDistance(t.Route, tour.Route); d < min {
+			min = d
+		}
+	}
+	return min
+}
+
+// Add inserts the tour if it isn't already in the pool and it is either one of
+// the MaxSize best tours or at least MinDistance edges from every tour in the
+// pool. A full pool evicts its worst tour, or if the tour is only being kept
+// for diversity, the worst tour that is within MinDistance of another tour.
+func (p *SolutionPool) Add(t Tour) bool {
+	if len(p.tours) > 0 && p.closest(t, -1) == 0 {
+		return false
+	}
+	t.Route = append([]int{}, t.Route...)
+	evict := -1
+	if p.MaxSize > 0 && len(p.tours) >= p.MaxSize {
+		worst := len(p.tours) - 1
+		if t.Cost < p.tours[worst].Cost {
+			evict = worst
+		} else if p.closest(t, -1) >= p.MinDistance {
+			for i := worst; i >= 0; i-- {
+				if p.closest(p.tours[i], i) < p.MinDistance {
+					evict = i
+					break
+				}
+			}
+		}
+		if evict == -1 {
+			return false
+		}
+		p.tours = append(p.tours[:evict], p.tours[evict+1:]...)
+	}
+	i := sort.Search(len(p.tours), func(i int) bool {
+		return p.tours[i].Cost > t.Cost
+	})
+	p.tours = append(p.tours, Tour{})
+	copy(p.tours[i+1:], p.tours[i:])
+	p.tours[i] = t
+	return true
+}
+
+// Len is the number of tours in the pool
+func (p *SolutionPool) Len() int {
+	return len(p.tours)
+}
+
+// Best returns the lowest cost tour in the pool
+func (p *SolutionPool) Best() Tour {
+	if len(p.tours) == 0 {
+		panic("solution pool is empty")
+	}
+	return p.tours[0]
+}
+
+// Diverse returns k tours by starting with the best tour and repeatedly
+// picking the tour farthest from the tours already picked
+func (p *SolutionPool) Diverse(k int) []Tour {
+	if k > len(p.tours) {
+		k = len(p.tours)
+	}
+	if k <= 0 {
+		return nil
+	}
+	picked := []Tour{p.tours[0]}
+	used := make([]bool, len(p.tours))
+	used[0] = true
+	for len(picked) < k {
+		farthest, index := -1, -1
+		for i, tour := range p.tours {
+			if used[i] {
+				continue
+			}
+			min := len(tour.Route)
+			for _, q := range picked {
+				if d := TourEdgeDistance(tour.Route, q.Route); d < min {
+					min = d
+				}
+			}
+			if min > farthest {
+				farthest, index = min, i
+			}
+		}
+		used[index] = true
+		picked = append(picked, p.tours[index])
+	}
+	return picked
+}
+
+// TourEntropy measures how diverse a population of tours is with the Shannon
+// entropy H = -Σ p log p of the edges, where p is the fraction of the tours in
+// which a city is followed by another, averaged over the cities. It is zero
+// for identical tours and grows as the tours spread over more edges. The edges
+// are directed so a tour and its reverse differ.
+func TourEntropy(population []Tour, size int) float64 {
+	if len(population) == 0 {
+		return 0
+	}
+	counts := make([]int, size*size)
+	for _, tour := range population {
+		for i := 1; i < len(tour.Route); i++ {
+			counts[tour.Route[i-1]*size+tour.Route[i]]++
+		}
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(len(population))
+		entropy -= p * math.Log(p)
+	}
+	return entropy / float64(size)
+}
+
+// MultiStart runs iterated local search from runs different seeds collecting
+// the tours in the pool and returns the best tour, in debug mode the best
+// cost and the entropy of the pool are printed after each run
+func MultiStart(dist []float64, size, runs int, pool *SolutionPool) Tour {
+	for seed := 1; seed <= runs; seed++ {
+		pool.Add(IteratedLocalSearch(dist, size, ILSOptions{
+			Iterations: size,
+			Seed:       int64(seed),
+		}))
+		if *FlagDebug {
+			fmt.Println(seed, pool.Best().Cost, TourEntropy(pool.tours, size))
+		}
+	}
+	return pool.Best()
+}
+
+// diverseAttempts is the number of solves per run DiverseMultiStart makes
+// before it gives up on finding another diverse tour
+const diverseAttempts = 10
+
+// DiverseMultiStart solves the instance until it has runs tours that are
+// pairwise at least minDiversity edges apart and returns the best of them
+// with the number of diverse tours found. Solvers that support warm starting
+// start from a different random tour seeded with the attempt number each
+// time, other solvers only give different tours if they are random. It gives
+// up after diverseAttempts*runs solves, the first tour is always kept so at
+// least one is found. runs must be positive.
+func DiverseMultiStart(solver Solver, dist []float64, size, runs int, minDiversity int) (Tour, int) {
+	if runs < 1 {
+		panic(fmt.Sprintf("DiverseMultiStart requires at least 1 run, got %d", runs))
+	}
+	tours := diverseStarts(solver, dist, size, runs, minDiversity)
+	return BestOf(tours...), len(tours)
+}
+
+// diverseStarts returns the diverse tours of DiverseMultiStart
+func diverseStarts(solver Solver, dist []float64, size, runs int, minDiversity int) []Tour {
+	tours := make([]Tour, 0, runs)
+	for attempt := 1; attempt <= diverseAttempts*runs && len(tours) < runs; attempt++ {
+		start := InitialTour(dist, size, InitRandom, rand.New(rand.NewSource(int64(attempt)))).Route
+		tour := solveFrom(solver, dist, size, start)
+		diverse := true
+		for _, t := range tours {
+			if TourEdgeDistance(t.Route, tour.Route) < minDiversity {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			tours = append(tours, tour)
+		}
+	}
+	return tours
+}
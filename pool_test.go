@@ -0,0 +1,131 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSolutionPoolAdd(t *testing.T) {
+	pool := SolutionPool{MaxSize: 2, MinDistance: 3}
+	route := func(route ...int) Tour {
+		return Tour{Cost: TourCost(canonical, Size, route), Route: route}
+	}
+	if !pool.Add(route(0, 1, 3, 2, 0)) {
+		t.Error("Expected the first tour to be added")
+	}
+	if !pool.Add(route(0, 2, 1, 3, 0)) {
+		t.Error("Expected a second tour to be added")
+	}
+	// the reverse of a tour in the pool is a duplicate
+	if pool.Add(route(0, 2, 3, 1, 0)) {
+		t.Error("Expected a duplicate tour to be rejected")
+	}
+	if pool.Len() != 2 {
+		t.Errorf("Expected 2 tours, got %d", pool.Len())
+	}
+	// the optimal tour evicts the worst tour
+	if !pool.Add(route(0, 1, 2, 3, 0)) {
+		t.Error("Expected a better tour to be added")
+	}
+	if pool.Len() != 2 {
+		t.Errorf("Expected 2 tours, got %d", pool.Len())
+	}
+	if best := pool.Best(); best.Cost != 97 {
+		t.Errorf("Expected the best cost to be 97, got %f", best.Cost)
+	}
+	for _, tour := range pool.tours {
+		if tour.Cost == 141 {
+			t.Error("Expected the worst tour to be evicted")
+		}
+	}
+}
+
+func TestSolutionPoolUnbounded(t *testing.T) {
+	var pool SolutionPool
+	for _, route := range [][]int{{0, 1, 2, 3, 0}, {0, 1, 3, 2, 0}, {0, 2, 1, 3, 0}} {
+		if !pool.Add(Tour{Cost: TourCost(canonical, Size, route), Route: route}) {
+			t.Errorf("Expected %v to be added to an unbounded pool", route)
+		}
+	}
+	if pool.Len() != 3 {
+		t.Errorf("Expected 3 tours, got %d", pool.Len())
+	}
+}
+
+func TestSolutionPoolDiverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 10)
+	pool := SolutionPool{MaxSize: 8, MinDistance: 4}
+	best := MultiStart(dist, 10, 16, &pool)
+	for _, tour := range pool.tours {
+		if tour.Cost < best.Cost {
+			t.Errorf("Expected %f to be the best cost, got %f", best.Cost, tour.Cost)
+		}
+	}
+	diverse := pool.Diverse(3)
+	if len(diverse) != 3 || diverse[0].Cost != best.Cost {
+		t.Fatalf("Expected 3 tours starting with the best, got %v", diverse)
+	}
+	for i := range diverse {
+		for j := range diverse {
+			if i != j && TourEdgeDistance(diverse[i].Route, diverse[j].Route) == 0 {
+				t.Errorf("Expected tours %d and %d to be different", i, j)
+			}
+		}
+	}
+}
+
+func TestTourEntropy(t *testing.T) {
+	tour := Tour{Route: []int{0, 1, 2, 3, 0}}
+	if h := TourEntropy([]Tour{tour, tour, tour}, 4); h != 0 {
+		t.Errorf("Expected entropy 0 for identical tours, got %f", h)
+	}
+	diverse := []Tour{tour, {Route: []int{0, 2, 1, 3, 0}}, {Route: []int{0, 3, 1, 2, 0}}}
+	if h := TourEntropy(diverse, 4); h <= 0 {
+		t.Errorf("Expected positive entropy for different tours, got %f", h)
+	}
+}
+
+func TestDiverseMultiStart(t *testing.T) {
+	twoOpt := WarmSolverFunc(func(dist []float64, size int, tour []int) Tour {
+		cost, route := TwoOpt(dist, size, tour)
+		return Tour{Cost: cost, Route: route}
+	})
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		dist := randomInstance(rng, 8)
+		tours := diverseStarts(twoOpt, dist, 8, 4, 2)
+		// an 8 city instance can have fewer than 4 different 2-opt optima
+		if len(tours) < 2 {
+			t.Fatalf("Expected at least 2 diverse tours, got %d", len(tours))
+		}
+		for j := range tours {
+			for k := j + 1; k < len(tours); k++ {
+				if d := TourEdgeDistance(tours[j].Route, tours[k].Route); d < 2 {
+					t.Errorf("Expected tours %d and %d to be at least 2 edges apart, got %d", j, k, d)
+				}
+			}
+		}
+		best, found := DiverseMultiStart(twoOpt, dist, 8, 4, 2)
+		if best.Cost != BestOf(tours...).Cost {
+			t.Errorf("Expected the best of the diverse tours %f, got %f", BestOf(tours...).Cost, best.Cost)
+		}
+		if found != len(tours) {
+			t.Errorf("Expected %d diverse tours to be found, got %d", len(tours), found)
+		}
+	}
+	// a deterministic solver can't find a second diverse tour
+	if tours := diverseStarts(SolverFunc(Christofides), canonical, Size, 2, 1); len(tours) != 1 {
+		t.Errorf("Expected 1 tour from a deterministic solver, got %d", len(tours))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for no runs")
+		}
+	}()
+	DiverseMultiStart(twoOpt, canonical, Size, 0, 1)
+}
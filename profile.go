@@ -0,0 +1,135 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime/trace"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ProfileDistance is the time spent computing tour costs from the
+	// distances
+	ProfileDistance = iota
+	// ProfileMove is the time spent evaluating local search moves, including
+	// the distance computations of the moves
+	ProfileMove
+	// ProfileData is the time spent changing routes
+	ProfileData
+)
+
+// ProfileCategories are the names of the timing categories
+var ProfileCategories = []string{"distance", "move", "data"}
+
+// probe measures the time spent in each category by a solve, a nil probe
+// measures nothing
+type probe struct {
+	durations [3]int64
+	counts    [3]int64
+}
+
+// span is a region being measured
+type span struct {
+	start  time.Time
+	region *trace.Region
+}
+
+// start starts measuring a region of the category, it is also a trace region.
+// The nil check is kept apart from begin so that it is inlined.
+func (p *probe) start(category int) span {
+	if p == nil {
+		return span{}
+	}
+	return p.begin(category)
+}
+
+// begin starts measuring a region of the category
+func (p *probe) begin(category int) span {
+	return span{
+		start:  time.Now(),
+		region: trace.StartRegion(context.Background(), ProfileCategories[category]),
+	}
+}
+
+// end adds the time of the region to its category
+func (p *probe) end(category int, s span) {
+	if p != nil {
+		p.finish(category, s)
+	}
+}
+
+// finish adds the time of the region to its category
+func (p *probe) finish(category int, s span) {
+	atomic.AddInt64(&p.durations[category], int64(time.Since(s.start)))
+	atomic.AddInt64(&p.counts[category], 1)
+	s.region.End()
+}
+
+// reverse reverses route[i:j+1] measuring it as data
+func (p *probe) reverse(route []int, i, j int) {
+	if p == nil {
+		reverse(route, i, j)
+		return
+	}
+	s := p.begin(ProfileData)
+	reverse(route, i, j)
+	p.finish(ProfileData, s)
+}
+
+// tourCost is TourCost measured as distance
+func (p *probe) tourCost(dist []float64, size int, route []int) float64 {
+	if p == nil {
+		return TourCost(dist, size, route)
+	}
+	s := p.begin(ProfileDistance)
+	cost := TourCost(dist, size, route)
+	p.finish(ProfileDistance, s)
+	return cost
+}
+
+// ProfiledSolver is a solver that writes how long the inner solver spent in
+// each timing category to the log after solving. The categories are measured
+// in TwoOpt and simulated annealing, so only the solvers built on them that
+// pass the probe on, such as the ils and twoopt default solvers and the sa
+// solver of NewSolverFromJSON, are measured. For other solvers just the total
+// time is measured.
+type ProfiledSolver struct {
+	inner Solver
+	log   io.Writer
+}
+
+// NewProfiledSolver wraps the solver
+func NewProfiledSolver(inner Solver, log io.Writer) *ProfiledSolver {
+	return &ProfiledSolver{inner: inner, log: log}
+}
+
+// Solve solves with the inner solver and writes the timing of each category
+// with the number of regions measured
+func (p *ProfiledSolver) Solve(dist []float64, size int) Tour {
+	var measured probe
+	start := time.Now()
+	var tour Tour
+	if inner, ok := p.inner.(hookedSolver); ok {
+		tour = inner.solveHooked(dist, size, hooks{probe: &measured})
+	} else {
+		tour = p.inner.Solve(dist, size)
+	}
+	elapsed := time.Since(start)
+	fmt.Fprintf(p.log, "total %v\n", elapsed)
+	for i, category := range ProfileCategories {
+		fmt.Fprintf(p.log, "%s %v %d\n", category,
+			time.Duration(atomic.LoadInt64(&measured.durations[i])), atomic.LoadInt64(&measured.counts[i]))
+	}
+	return tour
+}
+
+// SupportsWarmStart is false
+func (p *ProfiledSolver) SupportsWarmStart() bool {
+	return false
+}
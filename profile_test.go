@@ -0,0 +1,60 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfiledSolver(t *testing.T) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 10)
+	sa, err := NewSolverFromJSON(`{"method": "sa", "iterations": 1000}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solvers := map[string]Solver{
+		"twoopt": DefaultSolvers()["twoopt"],
+		"ils":    DefaultSolvers()["ils"],
+		"sa":     sa,
+	}
+	var log bytes.Buffer
+	for name, inner := range solvers {
+		log.Reset()
+		tour := NewProfiledSolver(inner, &log).Solve(dist, 10)
+		if err := VerifyTour(dist, 10, tour); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+		if len(lines) != len(ProfileCategories)+1 || !strings.HasPrefix(lines[0], "total ") {
+			t.Fatalf("Expected the total and %d categories for %s, got %s", len(ProfileCategories), name, log.String())
+		}
+		for i, category := range ProfileCategories {
+			fields := strings.Fields(lines[i+1])
+			if len(fields) != 3 || fields[0] != category {
+				t.Fatalf("Expected a timing for %s of %s, got %q", category, name, lines[i+1])
+			}
+			if _, err := time.ParseDuration(fields[1]); err != nil {
+				t.Errorf("Expected a duration for %s of %s, got %q: %v", category, name, fields[1], err)
+			}
+			if count, err := strconv.Atoi(fields[2]); err != nil || count == 0 {
+				t.Errorf("Expected regions of %s for %s, got %q", category, name, lines[i+1])
+			}
+		}
+	}
+
+	// a solver that doesn't pass the probe on is only timed
+	log.Reset()
+	NewProfiledSolver(SolverFunc(solvers["twoopt"].Solve), &log).Solve(dist, 10)
+	for _, line := range strings.Split(strings.TrimSpace(log.String()), "\n")[1:] {
+		if !strings.HasSuffix(line, " 0s 0") {
+			t.Errorf("Expected no regions without the probe, got %q", line)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pointlander/pagerank"
+)
+
+// pageRanks computes the page rank of each city with the links weighted by
+// dist
+func pageRanks(dist []float64, size int, dampingFactor, tol float64) []float64 {
+	graph := pagerank.NewGraph64()
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i == j {
+				continue
+			}
+			graph.Link(uint64(i), uint64(j), dist[i*size+j])
+		}
+	}
+	ranks := make([]float64, size)
+	graph.Rank(dampingFactor, tol, func(node uint64, rank float64) {
+		ranks[node] = rank
+	})
+	return ranks
+}
+
+// PageRankImprove improves a tour with 2-opt moves weighted by the page rank
+// of the cities. Of the improving moves the one that moves the most rank
+// earlier in the tour is made, until no move improves the tour.
+func PageRankImprove(dist []float64, size int, tour []int, dampingFactor, tol float64) (float64, []int) {
+	ranks := pageRanks(dist, size, dampingFactor, tol)
+	route := append([]int{}, tour...)
+	cost := TourCost(dist, size, route)
+	n := len(route) - 1
+	for {
+		bestPriority, bestCost, bestI, bestJ := math.Inf(-1), 0.0, 0, 0
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				reverse(route, i, j)
+				c := TourCost(dist, size, route)
+				reverse(route, i, j)
+				if c >= cost {
+					continue
+				}
+				// the city at p moves to i+j-p, advancing 2p-i-j places
+				priority := 0.0
+				for p := i; p <= j; p++ {
+					priority += ranks[route[p]] * float64(2*p-i-j)
+				}
+				if priority > bestPriority || (priority == bestPriority && c < bestCost) {
+					bestPriority, bestCost, bestI, bestJ = priority, c, i, j
+				}
+			}
+		}
+		if math.IsInf(bestPriority, -1) {
+			return cost, route
+		}
+		reverse(route, bestI, bestJ)
+		cost = bestCost
+	}
+}
+
+// pageRankTour visits the cities in ascending rank order starting from the
+// city with the highest rank, like PageRank
+func pageRankTour(dist []float64, size int, ranks []float64) Tour {
+	cities := make([]int, size)
+	for i := range cities {
+		cities[i] = i
+	}
+	sort.SliceStable(cities, func(i, j int) bool {
+		return ranks[cities[i]] < ranks[cities[j]]
+	})
+	route := make([]int, 0, size+1)
+	route = append(route, cities[size-1])
+	route = append(route, cities[:size-1]...)
+	route = append(route, cities[size-1])
+	return Tour{
+		Cost:  TourCost(dist, size, route),
+		Route: route,
+	}
+}
+
+// PageRankDirected builds a tour from the page rank of the directed graph
+// with a link from i to j weighted by the cost of going from i to j
+func PageRankDirected(dist []float64, size int, damping, tol float64) Tour {
+	return pageRankTour(dist, size, pageRanks(dist, size, damping, tol))
+}
+
+// PageRankUndirected builds a tour from the page rank of the undirected graph
+// with the links in both directions weighted by the sum of the costs of
+// going from i to j and from j to i
+func PageRankUndirected(dist []float64, size int, damping, tol float64) Tour {
+	symmetric := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			symmetric[i*size+j] = dist[i*size+j] + dist[j*size+i]
+		}
+	}
+	return pageRankTour(dist, size, pageRanks(symmetric, size, damping, tol))
+}
+
+// HITS computes the hub and authority scores of the cities with a link from i
+// to j weighted by the cost of going from i to j
+func HITS(dist []float64, size int, iterations int) (hubs, authorities []float64) {
+	normalize := func(x []float64) {
+		sum := 0.0
+		for _, v := range x {
+			sum += v * v
+		}
+		if sum == 0 {
+			return
+		}
+		norm := math.Sqrt(sum)
+		for i := range x {
+			x[i] /= norm
+		}
+	}
+	hubs, authorities = make([]float64, size), make([]float64, size)
+	for i := range hubs {
+		hubs[i] = 1
+	}
+	normalize(hubs)
+	for iteration := 0; iteration < iterations; iteration++ {
+		for j := 0; j < size; j++ {
+			authorities[j] = 0
+			for i := 0; i < size; i++ {
+				if i != j {
+					authorities[j] += dist[i*size+j] * hubs[i]
+				}
+			}
+		}
+		normalize(authorities)
+		for i := 0; i < size; i++ {
+			hubs[i] = 0
+			for j := 0; j < size; j++ {
+				if i != j {
+					hubs[i] += dist[i*size+j] * authorities[j]
+				}
+			}
+		}
+		normalize(hubs)
+	}
+	return hubs, authorities
+}
+
+// HITSTour visits the cities in descending authority order starting from the
+// city with the highest authority
+func HITSTour(dist []float64, size int, iterations int) Tour {
+	_, authorities := HITS(dist, size, iterations)
+	cities := make([]int, size)
+	for i := range cities {
+		cities[i] = i
+	}
+	sort.SliceStable(cities, func(i, j int) bool {
+		return authorities[cities[i]] > authorities[cities[j]]
+	})
+	route := append(cities, cities[0])
+	return Tour{
+		Cost:  TourCost(dist, size, route),
+		Route: route,
+	}
+}
+
+// PageRankWeightedNN runs nearest neighbor from every city on the costs
+// divided by the page ranks of both cities, so the edges of high rank cities
+// are discounted and they are visited early
+func PageRankWeightedNN(dist []float64, size int) Tour {
+	ranks := pageRanks(dist, size, .85, 0.000001)
+	weighted := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			weighted[i*size+j] = dist[i*size+j] / (ranks[i] * ranks[j])
+		}
+	}
+	best := Tour{Cost: math.MaxFloat64}
+	for offset := 0; offset < size; offset++ {
+		route := nearestNeighbor(weighted, size, offset).Route
+		if cost := TourCost(dist, size, route); cost < best.Cost {
+			best.Cost, best.Route = cost, route
+		}
+	}
+	return best
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestPageRankImprove(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	better, worse, total, twoOptTotal := 0, 0, 0.0, 0.0
+	for i := 0; i < 100; i++ {
+		dist := randomInstance(rng, 8)
+		nn := nearestNeighbor(dist, 8, 0)
+		cost, route := PageRankImprove(dist, 8, nn.Route, .85, 0.000001)
+		if err := ValidateTour(route, 8); err != nil {
+			t.Fatal(err)
+		}
+		if c := TourCost(dist, 8, route); c != cost {
+			t.Fatalf("Expected cost %f, got %f", c, cost)
+		}
+		if cost > nn.Cost {
+			t.Errorf("Expected at most %f, got %f", nn.Cost, cost)
+		}
+		twoOpt, _ := TwoOpt(dist, 8, nn.Route)
+		switch {
+		case cost < twoOpt:
+			better++
+		case cost > twoOpt:
+			worse++
+		}
+		total += cost
+		twoOptTotal += twoOpt
+	}
+	t.Logf("PageRankImprove beat 2-opt %d times and lost %d times, total cost %f vs %f",
+		better, worse, total, twoOptTotal)
+}
+
+func TestPageRankDirected(t *testing.T) {
+	// going forward around the cycle 0 1 2 3 4 is cheaper than going back, the
+	// costs grow with the city so the ranks aren't uniform
+	size := 5
+	dist := make([]float64, size*size)
+	transpose := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i == j {
+				continue
+			}
+			dist[i*size+j] = float64(1 + (j-i+size)%size*(i+1))
+			transpose[j*size+i] = dist[i*size+j]
+		}
+	}
+
+	directed := pageRanks(dist, size, .85, 0.000001)
+	if reflect.DeepEqual(directed, pageRanks(transpose, size, .85, 0.000001)) {
+		t.Errorf("Expected the directed ranks to depend on direction, got %v", directed)
+	}
+	undirected := PageRankUndirected(dist, size, .85, 0.000001)
+	reversed := PageRankUndirected(transpose, size, .85, 0.000001)
+	if !equal(undirected.Route, reversed.Route) {
+		t.Errorf("Expected the undirected route %v to ignore direction, got %v", undirected.Route, reversed.Route)
+	}
+
+	for _, tour := range []Tour{PageRankDirected(dist, size, .85, 0.000001), undirected} {
+		if err := ValidateTour(tour.Route, size); err != nil {
+			t.Fatal(err)
+		}
+		if c := TourCost(dist, size, tour.Route); c != tour.Cost {
+			t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+		}
+	}
+}
+
+func TestHITS(t *testing.T) {
+	// every city links to city 0 with a heavy weight
+	size := 4
+	dist := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if i != j {
+				dist[i*size+j] = 1
+			}
+		}
+		if i != 0 {
+			dist[i*size] = 10
+		}
+	}
+	hubs, authorities := HITS(dist, size, 64)
+	for i := 1; i < size; i++ {
+		if authorities[0] <= authorities[i] {
+			t.Errorf("Expected city 0 to have the highest authority, got %v", authorities)
+		}
+		if hubs[0] >= hubs[i] {
+			t.Errorf("Expected city 0 to have the lowest hub score, got %v", hubs)
+		}
+	}
+	tour := HITSTour(dist, size, 64)
+	if err := ValidateTour(tour.Route, size); err != nil {
+		t.Fatal(err)
+	}
+	if tour.Route[0] != 0 {
+		t.Errorf("Expected the tour to start at city 0, got %v", tour.Route)
+	}
+	if c := TourCost(dist, size, tour.Route); c != tour.Cost {
+		t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+	}
+}
+
+func TestPageRankWeightedNN(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 16; i++ {
+		dist := randomInstance(rng, 8)
+		tour := PageRankWeightedNN(dist, 8)
+		if err := ValidateTour(tour.Route, 8); err != nil {
+			t.Fatal(err)
+		}
+		if c := TourCost(dist, 8, tour.Route); c != tour.Cost {
+			t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// NeuralRLOptions are the options for NeuralRL
+type NeuralRLOptions struct {
+	// Iterations is the number of tours sampled from the policy
+	Iterations int
+	// LearningRate is the step size of the gradient ascent
+	LearningRate float64
+	// Seed seeds the random number generator
+	Seed int64
+}
+
+// policy is a softmax policy over the next city, with a logit for each pair
+// of cities
+type policy struct {
+	size   int
+	logits []float64
+	// probabilities are the probabilities of the last call to distribution
+	probabilities []float64
+}
+
+// distribution computes the probabilities of moving from city to each of the
+// unvisited cities
+func (p *policy) distribution(city int, visited []bool) []float64 {
+	max := math.Inf(-1)
+	for j := 0; j < p.size; j++ {
+		if !visited[j] {
+			max = math.Max(max, p.logits[city*p.size+j])
+		}
+	}
+	sum := 0.0
+	for j := 0; j < p.size; j++ {
+		p.probabilities[j] = 0
+		if visited[j] {
+			continue
+		}
+		p.probabilities[j] = math.Exp(p.logits[city*p.size+j] - max)
+		sum += p.probabilities[j]
+	}
+	for j := range p.probabilities {
+		p.probabilities[j] /= sum
+	}
+	return p.probabilities
+}
+
+// sample samples a tour starting at city 0 from the policy, accumulating the
+// gradient of the log probability of the tour in gradient. If greedy the most
+// probable city is always chosen.
+func (p *policy) sample(rng *rand.Rand, gradient []float64, greedy bool) []int {
+	visited := make([]bool, p.size)
+	state := 0
+	visited[state] = true
+	route := make([]int, 0, p.size+1)
+	route = append(route, state)
+	for i := 0; i < p.size-1; i++ {
+		probabilities := p.distribution(state, visited)
+		next := -1
+		if greedy {
+			for j, probability := range probabilities {
+				if !visited[j] && (next < 0 || probability > probabilities[next]) {
+					next = j
+				}
+			}
+		} else {
+			spin := rng.Float64()
+			for j, probability := range probabilities {
+				if visited[j] {
+					continue
+				}
+				next = j
+				if spin -= probability; spin <= 0 {
+					break
+				}
+			}
+		}
+		if gradient != nil {
+			// the gradient of log softmax is the one hot choice minus the
+			// probabilities
+			for j, probability := range probabilities {
+				gradient[state*p.size+j] -= probability
+			}
+			gradient[state*p.size+next]++
+		}
+		state = next
+		visited[state] = true
+		route = append(route, state)
+	}
+	return append(route, route[0])
+}
+
+// NeuralRL trains a softmax policy over the next city with the REINFORCE
+// policy gradient to directly minimize the tour cost. The reward is the
+// negative tour cost relative to the nearest neighbor cost, and a moving
+// average of the reward is the baseline.
+func NeuralRL(dist []float64, size int, opts NeuralRLOptions) Tour {
+	_, best := neuralRL(dist, size, opts)
+	return best
+}
+
+// neuralRL is NeuralRL returning the trained policy as well
+func neuralRL(dist []float64, size int, opts NeuralRLOptions) (*policy, Tour) {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	p := &policy{
+		size:          size,
+		logits:        make([]float64, size*size),
+		probabilities: make([]float64, size),
+	}
+	best := nearestNeighbor(dist, size, 0)
+	scale := best.Cost
+	if scale <= 0 {
+		scale = 1
+	}
+	gradient := make([]float64, size*size)
+	baseline, first := 0.0, true
+	for i := 0; i < opts.Iterations; i++ {
+		for j := range gradient {
+			gradient[j] = 0
+		}
+		route := p.sample(rng, gradient, false)
+		cost := TourCost(dist, size, route)
+		if cost < best.Cost {
+			best = Tour{Cost: cost, Route: route}
+		}
+		reward := -cost / scale
+		if first {
+			baseline, first = reward, false
+		}
+		advantage := reward - baseline
+		for j, g := range gradient {
+			p.logits[j] += opts.LearningRate * advantage * g
+		}
+		baseline = .9*baseline + .1*reward
+	}
+	route := p.sample(rng, nil, true)
+	if cost := TourCost(dist, size, route); cost < best.Cost {
+		best = Tour{Cost: cost, Route: route}
+	}
+	return p, best
+}
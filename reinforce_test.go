@@ -0,0 +1,93 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// tourProbability is the probability of the policy sampling the tour in
+// either direction, the tour must start at city 0
+func tourProbability(p *policy, route []int) float64 {
+	probability := func(route []int) float64 {
+		visited := make([]bool, p.size)
+		visited[route[0]] = true
+		probability := 1.0
+		for i := 1; i < len(route)-1; i++ {
+			probability *= p.distribution(route[i-1], visited)[route[i]]
+			visited[route[i]] = true
+		}
+		return probability
+	}
+	reversed := make([]int, len(route))
+	for i, city := range route {
+		reversed[len(route)-1-i] = city
+	}
+	return probability(route) + probability(reversed)
+}
+
+func TestNeuralRLPolicy(t *testing.T) {
+	const size = 6
+	dist := randomInstance(rand.New(rand.NewSource(1)), size)
+	optimal := IteratedLocalSearch(dist, size, ILSOptions{Iterations: 64, Seed: 1})
+	if nn := nearestNeighbor(dist, size, 0); nn.Cost <= optimal.Cost {
+		t.Fatalf("Expected nearest neighbor %f to be worse than %f", nn.Cost, optimal.Cost)
+	}
+	// start the optimal tour at city 0
+	route := append([]int{}, optimal.Route[:size]...)
+	for route[0] != 0 {
+		route = append(route[1:], route[0])
+	}
+	route = append(route, 0)
+	last := 0.0
+	for _, iterations := range []int{0, 64, 256, 1024} {
+		p, _ := neuralRL(dist, size, NeuralRLOptions{Iterations: iterations, LearningRate: 1, Seed: 1})
+		probability := tourProbability(p, route)
+		if probability <= last {
+			t.Errorf("Expected the probability of the optimal tour to rise above %f after %d iterations, got %f",
+				last, iterations, probability)
+		}
+		last = probability
+	}
+	if last < .5 {
+		t.Errorf("Expected the policy to prefer the optimal tour, got probability %f", last)
+	}
+}
+
+func TestNeuralRL(t *testing.T) {
+	optimal, _ := Search(canonical)
+	rand.Seed(1)
+	autoencoder, _ := Neural(canonical)
+	tour := NeuralRL(canonical, Size, NeuralRLOptions{
+		Iterations:   256,
+		LearningRate: 1,
+		Seed:         1,
+	})
+	if err := ValidateTour(tour.Route, Size); err != nil {
+		t.Fatal(err)
+	}
+	if c := TourCost(canonical, Size, tour.Route); c != tour.Cost {
+		t.Errorf("Expected cost %f, got %f", c, tour.Cost)
+	}
+	if tour.Cost != optimal {
+		t.Errorf("Expected optimal cost %f, got %f", optimal, tour.Cost)
+	}
+	if tour.Cost > autoencoder {
+		t.Errorf("Expected at most the autoencoder cost %f, got %f", autoencoder, tour.Cost)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	rl, autoencoders := 0.0, 0.0
+	for i := 0; i < 16; i++ {
+		dist := randomInstance(rng, Size)
+		cost, _ := Neural(dist)
+		autoencoders += cost
+		rl += NeuralRL(dist, Size, NeuralRLOptions{Iterations: 256, LearningRate: 1, Seed: 1}).Cost
+	}
+	if rl > autoencoders {
+		t.Errorf("Expected at most the autoencoder total cost %f, got %f", autoencoders, rl)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// pathRelink returns the sequence of routes from guide to reference
+func pathRelink(guide, reference []int) [][]int {
+	route := rotate(guide, reference[0])
+	size := len(route) - 1
+	path := [][]int{append([]int{}, route...)}
+	position := make([]int, size)
+	for i, city := range route[:size] {
+		position[city] = i
+	}
+	for TourEdgeDistance(route, reference) > 0 {
+		best, bi, bj := -1, 0, 0
+		for i := 1; i < size; i++ {
+			if route[i] == reference[i] {
+				continue
+			}
+			// swap the city which belongs at i into place
+			j := position[reference[i]]
+			route[i], route[j] = route[j], route[i]
+			if d := TourEdgeDistance(route, reference); best == -1 || d < best {
+				best, bi, bj = d, i, j
+			}
+			route[i], route[j] = route[j], route[i]
+		}
+		if best == -1 {
+			break
+		}
+		route[bi], route[bj] = route[bj], route[bi]
+		position[route[bi]], position[route[bj]] = bi, bj
+		path = append(path, append([]int{}, route...))
+	}
+	return path
+}
+
+// PathRelink transforms guide into reference by repeatedly swapping a city
+// into its place in reference, choosing the swap that leaves the fewest
+// edges different from reference, and returns the best tour on the path
+func PathRelink(guide, reference []int, dist []float64, size int) Tour {
+	var best Tour
+	for i, route := range pathRelink(guide, reference) {
+		if cost := TourCost(dist, size, route); i == 0 || cost < best.Cost {
+			best.Cost, best.Route = cost, route
+		}
+	}
+	return best
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPathRelink(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 10)
+	for i := 0; i < 16; i++ {
+		guide, reference := append(rng.Perm(10), 0), append(rng.Perm(10), 0)
+		guide[10], reference[10] = guide[0], reference[0]
+		path := pathRelink(guide, reference)
+		if d := TourEdgeDistance(path[len(path)-1], reference); d != 0 {
+			t.Errorf("Expected distance 0 at the end of relinking, got %d", d)
+		}
+		tour := PathRelink(guide, reference, dist, 10)
+		if err := ValidateTour(tour.Route, 10); err != nil {
+			t.Errorf("Invalid tour: %v", err)
+		}
+		for _, route := range path {
+			if cost := TourCost(dist, 10, route); cost < tour.Cost {
+				t.Errorf("Expected %f to be the best cost on the path, got %f", tour.Cost, cost)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sort"
+)
+
+// savings runs the Clarke-Wright savings algorithm and returns the tour along
+// with the pairs of cities in the order they were merged
+func savings(dist []float64, size, depot int) (Tour, [][2]int) {
+	type Saving struct {
+		I, J   int
+		Saving float64
+	}
+	candidates := make([]Saving, 0, size*size/2)
+	for i := 0; i < size; i++ {
+		for j := i + 1; j < size; j++ {
+			if i == depot || j == depot {
+				continue
+			}
+			candidates = append(candidates, Saving{
+				I:      i,
+				J:      j,
+				Saving: dist[depot*size+i] + dist[depot*size+j] - dist[i*size+j],
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Saving > candidates[j].Saving
+	})
+
+	parent := make([]int, size)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	links := make([][]int, size)
+	merges := make([][2]int, 0, size)
+	for _, candidate := range candidates {
+		i, j := candidate.I, candidate.J
+		if len(links[i]) == 2 || len(links[j]) == 2 || find(i) == find(j) {
+			continue
+		}
+		links[i] = append(links[i], j)
+		links[j] = append(links[j], i)
+		parent[find(i)] = find(j)
+		merges = append(merges, [2]int{i, j})
+	}
+
+	route := make([]int, 0, size+1)
+	route = append(route, depot)
+	if size > 1 {
+		start := -1
+		for i := 0; i < size; i++ {
+			if i != depot && len(links[i]) < 2 {
+				start = i
+				break
+			}
+		}
+		previous, state := depot, start
+		for state != -1 {
+			route = append(route, state)
+			next := -1
+			for _, link := range links[state] {
+				if link != previous {
+					next = link
+				}
+			}
+			previous, state = state, next
+		}
+	}
+	route = append(route, depot)
+	return Tour{
+		Cost:  TourCost(dist, size, route),
+		Route: route,
+	}, merges
+}
+
+// SavingsAlgorithm uses the Clarke-Wright savings algorithm to solve the
+// symmetric traveling salesman problem. Every city starts on its own route
+// from the depot and routes are merged in order of the largest saving
+// d(depot,i) + d(depot,j) - d(i,j). The distances must be symmetric, see
+// Symmetrize.
+func SavingsAlgorithm(dist []float64, size, depot int) Tour {
+	tour, _ := savings(dist, size, depot)
+	return tour
+}
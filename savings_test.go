@@ -0,0 +1,39 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestSavingsAlgorithm(t *testing.T) {
+	dist, size := MustNewDenseMatrix([][]float64{
+		{0, 12, 11, 7, 10, 10},
+		{12, 0, 8, 5, 9, 12},
+		{11, 8, 0, 9, 14, 9},
+		{7, 5, 9, 0, 7, 9},
+		{10, 9, 14, 7, 0, 3},
+		{10, 12, 9, 9, 3, 0},
+	})
+	tour, merges := savings(dist, size, 0)
+	expected := [][2]int{{4, 5}, {1, 2}, {1, 3}, {2, 5}}
+	if len(merges) != len(expected) {
+		t.Fatalf("Expected merges %v, got %v", expected, merges)
+	}
+	for i, merge := range merges {
+		if merge != expected[i] {
+			t.Errorf("Expected merge %d to be %v, got %v", i, expected[i], merge)
+		}
+	}
+	if err := ValidateTour(tour.Route, size); err != nil {
+		t.Errorf("Invalid tour: %v", err)
+	}
+	if route := []int{0, 3, 1, 2, 5, 4, 0}; !equal(tour.Route, route) {
+		t.Errorf("Expected route %v, got %v", route, tour.Route)
+	}
+	if tour.Cost != TourCost(dist, size, tour.Route) {
+		t.Errorf("Expected cost %f, got %f", TourCost(dist, size, tour.Route), tour.Cost)
+	}
+}
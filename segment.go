@@ -0,0 +1,85 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// searchPath reorders the interior of the path optimally with Search, keeping
+// the first and the last city in place
+func searchPath(dist []float64, size int, path []int) {
+	k := len(path)
+	sub := make([]float64, k*k)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			sub[i*k+j] = dist[path[i]*size+path[j]]
+		}
+		// the only way into the first city is from the last city, so the
+		// cycle is the path closed by that edge
+		sub[i*k] = math.Inf(1)
+	}
+	sub[(k-1)*k] = 0
+	_, cycle := Search(sub)
+	cycle = rotate(cycle, 0)
+	ordered := make([]int, k)
+	for i, c := range cycle[:k] {
+		ordered[i] = path[c]
+	}
+	copy(path, ordered)
+}
+
+// SegmentedSolve improves the nearest neighbor tour from city 0 by solving
+// windows of windowSize consecutive cities optimally with Search, keeping the
+// first and the last city of each window in place. Each window starts overlap
+// cities before the end of the previous one, then 2-opt moves with an end
+// within overlap cities of the start of a window are made.
+func SegmentedSolve(dist []float64, size, windowSize, overlap int) Tour {
+	if windowSize < 3 || overlap < 1 || overlap >= windowSize {
+		panic(fmt.Sprintf("invalid window size %d with overlap %d", windowSize, overlap))
+	}
+	if windowSize >= size {
+		cost, route := Search(dist)
+		tour, _ := Tour{Cost: cost, Route: route}.Rotate(0)
+		return tour
+	}
+	route := nearestNeighbor(dist, size, 0).Route
+	step := windowSize - overlap
+	var joins []int
+	for start := 0; start < size; start += step {
+		end := start + windowSize
+		if end > size+1 {
+			end = size + 1
+		}
+		if end-start >= 4 {
+			searchPath(dist, size, route[start:end])
+		}
+		joins = append(joins, start)
+	}
+	cost := TourCost(dist, size, route)
+	near := func(i int) bool {
+		for _, join := range joins {
+			if i >= join-overlap && i <= join+overlap {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 1; i < size-1; i++ {
+		for j := i + 1; j < size; j++ {
+			if !near(i) && !near(j) {
+				continue
+			}
+			reverse(route, i, j)
+			if c := TourCost(dist, size, route); c < cost {
+				cost = c
+				continue
+			}
+			reverse(route, i, j)
+		}
+	}
+	return Tour{Cost: cost, Route: route}
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSegmentedSolve(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 4; i++ {
+		dist := randomInstance(rng, 16)
+		tour := SegmentedSolve(dist, 16, 6, 2)
+		if err := VerifyTour(dist, 16, tour); err != nil {
+			t.Fatal(err)
+		}
+		if nn := nearestNeighbor(dist, 16, 0); tour.Cost > nn.Cost {
+			t.Errorf("Expected at most the nearest neighbor cost %f, got %f", nn.Cost, tour.Cost)
+		}
+	}
+	path := []int{3, 0, 2, 1}
+	searchPath(canonical, Size, path)
+	if path[0] != 3 || path[3] != 1 {
+		t.Errorf("Expected the path to start at 3 and end at 1, got %v", path)
+	}
+	if cost := canonical[3*Size+path[1]] + canonical[path[1]*Size+path[2]] + canonical[path[2]*Size+1]; cost != 12+42+20 {
+		t.Errorf("Expected the shortest path to cost %d, got %f %v", 12+42+20, cost, path)
+	}
+}
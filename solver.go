@@ -0,0 +1,458 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Solver solves the traveling salesman problem
+type Solver interface {
+	Solve(dist []float64, size int) Tour
+	// SupportsWarmStart is true if the solver can start from a given tour
+	SupportsWarmStart() bool
+}
+
+// WarmSolver is a Solver that can start from a given tour
+type WarmSolver interface {
+	Solver
+	// SolveFrom solves starting from tour, tour is nil when there is no
+	// starting tour
+	SolveFrom(dist []float64, size int, tour []int) Tour
+}
+
+// SolverFunc adapts a function to the Solver interface
+type SolverFunc func(dist []float64, size int) Tour
+
+// Solve calls the function
+func (s SolverFunc) Solve(dist []float64, size int) Tour {
+	start := time.Now()
+	tour := s(dist, size)
+	tour.Elapsed = time.Since(start)
+	return tour
+}
+
+// SupportsWarmStart is false
+func (s SolverFunc) SupportsWarmStart() bool {
+	return false
+}
+
+// WarmSolverFunc adapts a function that starts from tour to the Solver
+// interface, tour is nil when there is no starting tour
+type WarmSolverFunc func(dist []float64, size int, tour []int) Tour
+
+// Solve calls the function without a starting tour
+func (s WarmSolverFunc) Solve(dist []float64, size int) Tour {
+	return s.SolveFrom(dist, size, nil)
+}
+
+// SolveFrom calls the function with the starting tour
+func (s WarmSolverFunc) SolveFrom(dist []float64, size int, tour []int) Tour {
+	start := time.Now()
+	t := s(dist, size, tour)
+	t.Elapsed = time.Since(start)
+	return t
+}
+
+// SupportsWarmStart is true
+func (s WarmSolverFunc) SupportsWarmStart() bool {
+	return true
+}
+
+// solveFrom solves starting from tour if the solver supports warm starting
+func solveFrom(solver Solver, dist []float64, size int, tour []int) Tour {
+	if warm, ok := solver.(WarmSolver); ok {
+		return warm.SolveFrom(dist, size, tour)
+	}
+	return solver.Solve(dist, size)
+}
+
+// Pipeline returns a solver that runs the solvers in order, each solver that
+// supports warm starting starts from the best tour found so far
+func Pipeline(solvers ...Solver) Solver {
+	return SolverFunc(func(dist []float64, size int) Tour {
+		var best Tour
+		for _, solver := range solvers {
+			tour := solveFrom(solver, dist, size, best.Route)
+			if best.Route == nil || tour.Cost < best.Cost {
+				best = tour
+			}
+		}
+		return best
+	})
+}
+
+// symmetric returns a solver that symmetrizes asymmetric distances with the
+// -symmetrize method before solving, the cost of the tour is computed on the
+// original distances
+func (s SolverFunc) symmetric() SolverFunc {
+	return func(dist []float64, size int) Tour {
+		if *FlagSymmetrize == "" || SymmetryCheck(dist, size) {
+			return s(dist, size)
+		}
+		tour := s(Symmetrize(dist, size, *FlagSymmetrize), size)
+		tour.Cost = TourCost(dist, size, tour.Route)
+		return tour
+	}
+}
+
+// hooks instrument a solve, a nil hook does nothing
+type hooks struct {
+	// probe measures the time spent in each category, see ProfiledSolver
+	probe *probe
+	// explainer records each decision, see ExplainedSolver
+	explainer *explainer
+}
+
+// hookedSolver is a solver that passes hooks on to the algorithms it uses
+type hookedSolver interface {
+	Solver
+	// solveHooked solves with the hooks
+	solveHooked(dist []float64, size int, h hooks) Tour
+}
+
+// hookedFunc is a solver that passes its hooks on to the algorithms it uses
+type hookedFunc func(dist []float64, size int, h hooks) Tour
+
+// Solve solves without hooks
+func (f hookedFunc) Solve(dist []float64, size int) Tour {
+	return f.solveHooked(dist, size, hooks{})
+}
+
+// solveHooked solves with the hooks
+func (f hookedFunc) solveHooked(dist []float64, size int, h hooks) Tour {
+	start := time.Now()
+	tour := f(dist, size, h)
+	tour.Elapsed = time.Since(start)
+	return tour
+}
+
+// SupportsWarmStart is false
+func (f hookedFunc) SupportsWarmStart() bool {
+	return false
+}
+
+// warmHookedFunc is a hookedFunc that starts from tour, tour is nil when there
+// is no starting tour
+type warmHookedFunc func(dist []float64, size int, tour []int, h hooks) Tour
+
+// Solve solves without a starting tour or hooks
+func (f warmHookedFunc) Solve(dist []float64, size int) Tour {
+	return f.solveHookedFrom(dist, size, nil, hooks{})
+}
+
+// SolveFrom solves starting from tour without hooks
+func (f warmHookedFunc) SolveFrom(dist []float64, size int, tour []int) Tour {
+	return f.solveHookedFrom(dist, size, tour, hooks{})
+}
+
+// solveHooked solves with the hooks without a starting tour
+func (f warmHookedFunc) solveHooked(dist []float64, size int, h hooks) Tour {
+	return f.solveHookedFrom(dist, size, nil, h)
+}
+
+// solveHookedFrom solves starting from tour with the hooks
+func (f warmHookedFunc) solveHookedFrom(dist []float64, size int, tour []int, h hooks) Tour {
+	start := time.Now()
+	t := f(dist, size, tour, h)
+	t.Elapsed = time.Since(start)
+	return t
+}
+
+// SupportsWarmStart is true
+func (f warmHookedFunc) SupportsWarmStart() bool {
+	return true
+}
+
+// fixedSolver is a solver that only works with Size cities, it passes its
+// hooks on to the algorithms it uses
+type fixedSolver func(a []float64, h hooks) (float64, []int)
+
+// Solve solves without hooks
+func (f fixedSolver) Solve(dist []float64, size int) Tour {
+	return f.solveHooked(dist, size, hooks{})
+}
+
+// solveHooked solves with the hooks
+func (f fixedSolver) solveHooked(dist []float64, size int, h hooks) Tour {
+	if size != Size {
+		panic("solver requires Size cities")
+	}
+	start := time.Now()
+	cost, route := f(dist, h)
+	return Tour{
+		Cost:    cost,
+		Route:   route,
+		Elapsed: time.Since(start),
+	}
+}
+
+// SupportsWarmStart is false
+func (f fixedSolver) SupportsWarmStart() bool {
+	return false
+}
+
+// fixed adapts a solver that only works with Size cities and has no hooks
+func fixed(solve func(a []float64) (float64, []int)) Solver {
+	return fixedSolver(func(a []float64, h hooks) (float64, []int) {
+		return solve(a)
+	})
+}
+
+// SizedSolvers returns the solvers that work with size cities
+func SizedSolvers(solvers map[string]Solver, size int) map[string]Solver {
+	sized := make(map[string]Solver, len(solvers))
+	for name, solver := range solvers {
+		if _, ok := solver.(fixedSolver); ok && size != Size {
+			continue
+		}
+		sized[name] = solver
+	}
+	return sized
+}
+
+// SeedFromInstance derives a seed from the instance and the name of the
+// algorithm with the FNV hash of the binary encoded distances and the name
+func SeedFromInstance(dist []float64, size int, algorithm string) int64 {
+	hash := fnv.New64a()
+	binary.Write(hash, binary.LittleEndian, uint64(size))
+	binary.Write(hash, binary.LittleEndian, dist)
+	hash.Write([]byte(algorithm))
+	return int64(hash.Sum64())
+}
+
+// solverSeed is the seed of the named default solver, derived from the
+// instance with -seed-from-instance and 1 otherwise
+func solverSeed(dist []float64, size int, algorithm string) int64 {
+	if *FlagSeedFromInstance {
+		return SeedFromInstance(dist, size, algorithm)
+	}
+	return 1
+}
+
+// defaultTabuOptions are the tabu search options of the default solvers
+func defaultTabuOptions(size int, seed int64) TabuOptions {
+	return TabuOptions{Iterations: 100 * size, Tenure: size / 2, Seed: seed}
+}
+
+// defaultILSOptions are the iterated local search options of the default
+// solvers
+func defaultILSOptions(size int, seed int64) ILSOptions {
+	return ILSOptions{Iterations: 10 * size, Seed: seed}
+}
+
+// defaultMCTSOptions are the Monte Carlo tree search options of the default
+// solvers
+func defaultMCTSOptions(size int, seed int64) MCTSOptions {
+	return MCTSOptions{Iterations: 1000 * size, ExplorationConst: 1, Seed: seed}
+}
+
+// DefaultSolvers returns the solvers to compare, the solvers from the
+// original experiments only work with Size cities
+func DefaultSolvers() map[string]Solver {
+	return seededSolvers(solverSeed)
+}
+
+// seededSolvers returns the default solvers with the random solvers seeded by
+// seed
+func seededSolvers(seed func(dist []float64, size int, algorithm string) int64) map[string]Solver {
+	return map[string]Solver{
+		"search": fixed(Search),
+		"pagerank": fixed(func(a []float64) (float64, []int) {
+			cost, nodes := PageRank(a)
+			route := make([]int, len(nodes))
+			for i, node := range nodes {
+				route[i] = int(node)
+			}
+			return cost, route
+		}),
+		"eigen": fixed(func(a []float64) (float64, []int) {
+			_, cost, route := Eigen(a)
+			return cost, route
+		}),
+		"eigen2": fixed(Eigen2),
+		"nearestneighbor": fixedSolver(func(a []float64, h hooks) (float64, []int) {
+			return explainedNearestNeighborAll(a, h.explainer)
+		}),
+		"neural2": fixed(func(a []float64) (float64, []int) {
+			return Neural2(a, rand.New(rand.NewSource(seed(a, Size, "neural2"))))
+		}),
+		"tabu": WarmSolverFunc(func(dist []float64, size int, tour []int) Tour {
+			opts := defaultTabuOptions(size, seed(dist, size, "tabu"))
+			opts.Tour = tour
+			return TabuSearch(dist, size, opts)
+		}),
+		"ils": hookedFunc(func(dist []float64, size int, h hooks) Tour {
+			return iteratedLocalSearch(dist, size, defaultILSOptions(size, seed(dist, size, "ils")), h)
+		}),
+		"twoopt": warmHookedFunc(func(dist []float64, size int, tour []int, h hooks) Tour {
+			if tour == nil {
+				tour = nearestNeighbor(dist, size, 0).Route
+			}
+			cost, route := twoOpt(dist, size, tour, h)
+			return Tour{Cost: cost, Route: route}
+		}),
+		"beam": SolverFunc(func(dist []float64, size int) Tour {
+			return BeamSearch(dist, size, size)
+		}),
+		"savings": SolverFunc(func(dist []float64, size int) Tour {
+			return SavingsAlgorithm(dist, size, 0)
+		}).symmetric(),
+		"christofides": SolverFunc(Christofides).symmetric(),
+		"mcts": SolverFunc(func(dist []float64, size int) Tour {
+			return MonteCarloTreeSearch(dist, size, defaultMCTSOptions(size, seed(dist, size, "mcts")))
+		}),
+	}
+}
+
+// solveOptions are the options of Instance.Solve
+type solveOptions struct {
+	seed      *int64
+	warmStart []int
+}
+
+// Option is an option of Instance.Solve
+type Option func(*solveOptions)
+
+// WithSeed seeds the random solvers with seed instead of the default seed
+func WithSeed(seed int64) Option {
+	return func(o *solveOptions) {
+		o.seed = &seed
+	}
+}
+
+// WithWarmStart starts a solver that supports warm starting from tour
+func WithWarmStart(tour []int) Option {
+	return func(o *solveOptions) {
+		o.warmStart = tour
+	}
+}
+
+// Solve solves the instance with the named default solver
+func (inst *Instance) Solve(method string, opts ...Option) (Tour, error) {
+	var options solveOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	seed := solverSeed
+	if options.seed != nil {
+		seed = func(dist []float64, size int, algorithm string) int64 {
+			return *options.seed
+		}
+	}
+	solver, ok := seededSolvers(seed)[method]
+	if !ok {
+		return Tour{}, fmt.Errorf("unknown solver %q", method)
+	}
+	if _, ok := solver.(fixedSolver); ok && inst.Size != Size {
+		return Tour{}, fmt.Errorf("solver %s requires %d cities, instance %s has %d", method, Size, inst.Name, inst.Size)
+	}
+	if options.warmStart != nil {
+		if !solver.SupportsWarmStart() {
+			return Tour{}, fmt.Errorf("solver %s does not support warm starting", method)
+		}
+		if err := ValidateTour(options.warmStart, inst.Size); err != nil {
+			return Tour{}, fmt.Errorf("invalid warm start tour: %w", err)
+		}
+	}
+	return solveFrom(solver, inst.Dist, inst.Size, options.warmStart), nil
+}
+
+// SolverInfo describes a default solver
+type SolverInfo struct {
+	Description string
+	// Complexity is the time complexity in the number of cities n
+	Complexity string
+	// Guarantee is the bound on the cost of the tour, empty if there is none
+	Guarantee string
+	// WarmStart is true if the solver can start from a given tour
+	WarmStart bool
+}
+
+// SolverInfos describes the default solvers by name
+var SolverInfos = map[string]SolverInfo{
+	"search":          {Description: "exhaustive search of every tour", Complexity: "O(n!)", Guarantee: "optimal"},
+	"pagerank":        {Description: "tour through the cities in PageRank order", Complexity: "O(n²)"},
+	"eigen":           {Description: "nearest neighbor on the scaled eigenvectors", Complexity: "O(n³)"},
+	"eigen2":          {Description: "nearest neighbor on the eigenvectors of the distances", Complexity: "O(n³)"},
+	"nearestneighbor": {Description: "nearest neighbor from every city", Complexity: "O(n³)"},
+	"neural2":         {Description: "nearest neighbor on a trained embedding", Complexity: "O(n⁴)"},
+	"tabu":            {Description: "tabu search with 2-opt moves", Complexity: "O(iterations·n³)", WarmStart: true},
+	"ils":             {Description: "iterated local search with 2-opt", Complexity: "O(iterations·n⁴)"},
+	"twoopt":          {Description: "2-opt from the nearest neighbor tour", Complexity: "O(n⁴)", WarmStart: true},
+	"beam":            {Description: "beam search keeping the cheapest partial tours", Complexity: "O(width·n⁴)"},
+	"savings":         {Description: "Clarke-Wright savings", Complexity: "O(n² log n)"},
+	"christofides":    {Description: "Christofides with greedy matching", Complexity: "O(n³)", Guarantee: "2 × optimal on metric instances"},
+	"mcts":            {Description: "Monte Carlo tree search", Complexity: "O(iterations·n²)"},
+}
+
+// ListMethods writes a table of the default solvers with their description,
+// complexity, guarantee and whether they can be warm started
+func ListMethods(w io.Writer) error {
+	names := make([]string, 0, len(SolverInfos))
+	for name := range DefaultSolvers() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	_, err := fmt.Fprintf(w, "%-16s %-18s %-32s %-10s %s\n", "Method", "Complexity", "Guarantee", "WarmStart", "Description")
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		info := SolverInfos[name]
+		guarantee := info.Guarantee
+		if guarantee == "" {
+			guarantee = "-"
+		}
+		warm := "no"
+		if info.WarmStart {
+			warm = "yes"
+		}
+		_, err = fmt.Fprintf(w, "%-16s %-18s %-32s %-10s %s\n", name, info.Complexity, guarantee, warm, info.Description)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RankedResult is the result of a solver in a comparison
+type RankedResult struct {
+	Name string
+	Tour Tour
+	Rank int
+}
+
+// CompareSolvers runs each solver on the instance and ranks them by cost,
+// solvers with the same cost share the same rank
+func CompareSolvers(solvers map[string]Solver, dist []float64, size int) []RankedResult {
+	results := make([]RankedResult, 0, len(solvers))
+	for name, solver := range solvers {
+		results = append(results, RankedResult{
+			Name: name,
+			Tour: solver.Solve(dist, size),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Tour.Cost != results[j].Tour.Cost {
+			return results[i].Tour.Cost < results[j].Tour.Cost
+		}
+		return results[i].Name < results[j].Name
+	})
+	for i := range results {
+		if i > 0 && results[i].Tour.Cost == results[i-1].Tour.Cost {
+			results[i].Rank = results[i-1].Rank
+		} else {
+			results[i].Rank = i + 1
+		}
+	}
+	return results
+}
@@ -0,0 +1,185 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCompareSolvers(t *testing.T) {
+	// each solver returns a fixed route on the canonical instance
+	route := func(route ...int) Solver {
+		return SolverFunc(func(dist []float64, size int) Tour {
+			return Tour{
+				Cost:  TourCost(dist, size, route),
+				Route: route,
+			}
+		})
+	}
+	solvers := map[string]Solver{
+		"a": route(0, 2, 1, 3, 0),
+		"b": route(0, 1, 3, 2, 0),
+		"c": route(0, 1, 2, 3, 0),
+		"d": route(0, 3, 2, 1, 0),
+	}
+	results := CompareSolvers(solvers, canonical, Size)
+	names, ranks := []string{"c", "d", "b", "a"}, []int{1, 1, 3, 4}
+	for i, result := range results {
+		if result.Name != names[i] || result.Rank != ranks[i] {
+			t.Errorf("Expected %s with rank %d at %d, got %s with rank %d",
+				names[i], ranks[i], i, result.Name, result.Rank)
+		}
+	}
+}
+
+func TestSeedFromInstance(t *testing.T) {
+	seed := SeedFromInstance(canonical, Size, "tabu")
+	if SeedFromInstance(append([]float64{}, canonical...), Size, "tabu") != seed {
+		t.Error("Expected the same seed for the same instance")
+	}
+	if SeedFromInstance(canonical, Size, "ils") == seed {
+		t.Error("Expected a different seed for a different algorithm")
+	}
+	other := append([]float64{}, canonical...)
+	other[1]++
+	if SeedFromInstance(other, Size, "tabu") == seed {
+		t.Error("Expected a different seed for a different instance")
+	}
+}
+
+func TestInstanceSolve(t *testing.T) {
+	instance := MustTestInstance("canonical4")
+	for name := range DefaultSolvers() {
+		tour, err := instance.Solve(name, WithSeed(42))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyTour(instance.Dist, instance.Size, tour); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	a, _ := instance.Solve("mcts", WithSeed(42))
+	b, _ := instance.Solve("mcts", WithSeed(42))
+	if !equal(a.Route, b.Route) {
+		t.Errorf("Expected the same route for the same seed, got %v and %v", a.Route, b.Route)
+	}
+	if _, err := instance.Solve("unknown"); err == nil {
+		t.Error("Expected an error for an unknown solver")
+	}
+	random8 := MustTestInstance("random8")
+	if _, err := random8.Solve("eigen"); err == nil {
+		t.Error("Expected an error for a solver that requires Size cities")
+	}
+	if tour, err := random8.Solve("tabu"); err != nil || tour.Cost != random8.Optimal {
+		t.Errorf("Expected tabu to find the optimal cost %f, got %f %v", random8.Optimal, tour.Cost, err)
+	}
+}
+
+func TestListMethods(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := ListMethods(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != len(DefaultSolvers())+1 {
+		t.Fatalf("Expected a header and %d solvers, got %d lines", len(DefaultSolvers()), len(lines))
+	}
+	for name := range DefaultSolvers() {
+		if SolverInfos[name].Complexity == "" {
+			t.Errorf("Expected %s to have a complexity", name)
+		}
+	}
+	if !strings.Contains(buffer.String(), "2 × optimal on metric instances") {
+		t.Errorf("Expected the guarantee of christofides, got\n%s", buffer.String())
+	}
+}
+
+// startSolver is a WarmSolver that returns the tour it starts from
+type startSolver struct{}
+
+func (startSolver) Solve(dist []float64, size int) Tour {
+	return nearestNeighbor(dist, size, 0)
+}
+
+func (startSolver) SolveFrom(dist []float64, size int, tour []int) Tour {
+	if tour == nil {
+		return nearestNeighbor(dist, size, 0)
+	}
+	return Tour{Cost: TourCost(dist, size, tour), Route: tour}
+}
+
+func (startSolver) SupportsWarmStart() bool {
+	return true
+}
+
+func TestWarmStart(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	iterations := map[bool]int{}
+	for i := 0; i < 20; i++ {
+		dist := randomInstance(rng, 12)
+		reference := IteratedLocalSearch(dist, 12, ILSOptions{Iterations: 1000, Seed: 1})
+		for seed := int64(1); seed <= 4; seed++ {
+			// the annealed tour, Pipeline returns the nearest neighbor tour
+			// if annealing doesn't improve it
+			var annealed Tour
+			anneal := WarmSolverFunc(func(dist []float64, size int, tour []int) Tour {
+				annealed = SimulatedAnnealing(dist, size, tour, SAOptions{
+					Iterations:  100000,
+					Temperature: 5,
+					Cooling:     .9999,
+					Target:      1.01 * reference.Cost,
+					Seed:        seed,
+					InitMethod:  InitRandom,
+				})
+				return annealed
+			})
+			nn := SolverFunc(func(dist []float64, size int) Tour {
+				return nearestNeighbor(dist, size, 0)
+			})
+			warm := Pipeline(nn, anneal).Solve(dist, 12)
+			if err := VerifyTour(dist, 12, warm); err != nil {
+				t.Fatal(err)
+			}
+			iterations[true] += annealed.Iterations
+			iterations[false] += anneal.Solve(dist, 12).Iterations
+		}
+	}
+	if iterations[true] >= iterations[false] {
+		t.Errorf("Expected the warm start to converge in fewer iterations than the cold start, got %d and %d",
+			iterations[true], iterations[false])
+	}
+
+	instance := MustTestInstance("random8")
+	_, route := Search(instance.Dist)
+	if tour, err := instance.Solve("tabu", WithWarmStart(route)); err != nil || tour.Cost != instance.Optimal {
+		t.Errorf("Expected tabu to keep the optimal cost %f, got %f %v", instance.Optimal, tour.Cost, err)
+	}
+	if _, err := instance.Solve("ils", WithWarmStart(route)); err == nil {
+		t.Error("Expected an error for a solver that doesn't support warm starting")
+	}
+	if _, err := instance.Solve("tabu", WithWarmStart(route[1:])); err == nil {
+		t.Error("Expected an error for an invalid tour")
+	}
+	start := []int{0, 2, 1, 3, 0}
+	if tour := solveFrom(startSolver{}, canonical, Size, start); !equal(tour.Route, start) {
+		t.Errorf("Expected any WarmSolver to start from %v, got %v", start, tour.Route)
+	}
+	for name, solver := range DefaultSolvers() {
+		if solver.SupportsWarmStart() != SolverInfos[name].WarmStart {
+			t.Errorf("Expected the warm start support of %s to match its SolverInfo", name)
+		}
+	}
+}
+
+func TestElapsed(t *testing.T) {
+	for name, solver := range DefaultSolvers() {
+		if tour := solver.Solve(canonical, Size); tour.Elapsed <= 0 {
+			t.Errorf("Expected %s to record its elapsed time, got %v", name, tour.Elapsed)
+		}
+	}
+}
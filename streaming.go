@@ -0,0 +1,33 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "context"
+
+// SolveStreaming solves the instance in the background with the solver of the
+// json configuration, see NewSolverFromJSON. The sa and tabu solvers send the
+// starting tour and each better tour over the channel as they are found, and
+// every solver sends its final tour last, so the costs never increase. The
+// channel is closed when the solver finishes. A caller that stops reading
+// before then must cancel the context, the remaining tours are then dropped.
+// The solver can't be interrupted, so it still runs to the end.
+func SolveStreaming(ctx context.Context, dist []float64, size int, config string) (<-chan Tour, error) {
+	tours := make(chan Tour)
+	send := func(t Tour) {
+		select {
+		case tours <- t:
+		case <-ctx.Done():
+		}
+	}
+	solver, err := newSolverFromJSON(config, send)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(tours)
+		send(solver.Solve(dist, size))
+	}()
+	return tours, nil
+}
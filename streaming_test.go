@@ -0,0 +1,69 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSolveStreaming(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 12)
+	for _, config := range []string{
+		`{"method": "sa", "init_method": "random"}`,
+		`{"method": "tabu", "init_method": "random"}`,
+		`{"method": "beam"}`,
+	} {
+		tours, err := SolveStreaming(context.Background(), dist, 12, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var costs []float64
+		for tour := range tours {
+			if err := VerifyTour(dist, 12, tour); err != nil {
+				t.Fatalf("Invalid tour streamed by %s: %v", config, err)
+			}
+			costs = append(costs, tour.Cost)
+		}
+		for i := 1; i < len(costs); i++ {
+			if costs[i] > costs[i-1] {
+				t.Errorf("Expected non-increasing costs from %s, got %v", config, costs)
+				break
+			}
+		}
+		if config != `{"method": "beam"}` && len(costs) < 3 {
+			t.Errorf("Expected improvements streamed by %s, got %v", config, costs)
+		}
+	}
+	if _, err := SolveStreaming(context.Background(), dist, 12, `{"method": "none"}`); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+}
+
+func TestSolveStreamingCancel(t *testing.T) {
+	dist := randomInstance(rand.New(rand.NewSource(1)), 12)
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	tours, err := SolveStreaming(ctx, dist, 12, `{"method": "sa", "init_method": "random"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// abandon the stream after the starting tour, the solver must finish
+	// without anyone reading the rest of the tours
+	<-tours
+	cancel()
+	deadline := time.Now().Add(10 * time.Second)
+	for runtime.NumGoroutine() > goroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the solver to finish after the stream was abandoned, %d goroutines are left of %d",
+				runtime.NumGoroutine(), goroutines)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// City is a city at a point in the plane. Geographic cities can use their
+// longitude and latitude, instances with only distances need a 2D projection
+// of the cities.
+type City struct {
+	X, Y float64
+}
+
+// CityDistances are the euclidean distances between the cities
+func CityDistances(cities []City) []float64 {
+	size := len(cities)
+	dist := make([]float64, size*size)
+	for i, a := range cities {
+		for j, b := range cities {
+			dist[i*size+j] = math.Hypot(a.X-b.X, a.Y-b.Y)
+		}
+	}
+	return dist
+}
+
+// SweepTour visits the cities in order of their polar angle around the
+// centroid. The angles are split into sectors with the same number of cities,
+// and the path through each sector is improved with 2-opt moves inside the
+// sector. The tour starts at city 0.
+func SweepTour(cities []City, sectors int) Tour {
+	size := len(cities)
+	dist := CityDistances(cities)
+	var cx, cy float64
+	for _, city := range cities {
+		cx += city.X / float64(size)
+		cy += city.Y / float64(size)
+	}
+	angles := make([]float64, size)
+	route := make([]int, size, size+1)
+	for i, city := range cities {
+		angles[i] = math.Atan2(city.Y-cy, city.X-cx)
+		route[i] = i
+	}
+	sort.SliceStable(route, func(i, j int) bool {
+		return angles[route[i]] < angles[route[j]]
+	})
+	route = append(route, route[0])
+	if sectors < 1 {
+		sectors = 1
+	} else if sectors > size {
+		sectors = size
+	}
+	cost := TourCost(dist, size, route)
+	for s := 0; s < sectors; s++ {
+		low, high := s*size/sectors, (s+1)*size/sectors
+		if low == 0 {
+			// the first city stays in place so the route stays closed
+			low = 1
+		}
+		improved := true
+		for improved {
+			improved = false
+			for i := low; i < high-1; i++ {
+				for j := i + 1; j < high; j++ {
+					reverse(route, i, j)
+					if c := TourCost(dist, size, route); c < cost {
+						cost, improved = c, true
+						continue
+					}
+					reverse(route, i, j)
+				}
+			}
+		}
+	}
+	tour, _ := Tour{Cost: cost, Route: route}.Rotate(0)
+	return tour
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSweepTour(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	sweepWins, nnWins := 0, 0
+	for i := 0; i < 100; i++ {
+		cities := make([]City, 20)
+		for j := range cities {
+			cities[j] = City{X: rng.Float64(), Y: rng.Float64()}
+		}
+		dist := CityDistances(cities)
+		sweep := SweepTour(cities, 4)
+		if err := VerifyTour(dist, 20, sweep); err != nil {
+			t.Fatal(err)
+		}
+		if sweep.Route[0] != 0 {
+			t.Errorf("Expected the tour to start at city 0, got %v", sweep.Route)
+		}
+		nn := nearestNeighbor(dist, 20, 0)
+		if sweep.Cost < nn.Cost {
+			sweepWins++
+		} else if nn.Cost < sweep.Cost {
+			nnWins++
+		}
+	}
+	// with 4 sectors sweep is shorter on 83 instances and nearest neighbor on 17
+	if sweepWins <= nnWins {
+		t.Errorf("Expected sweep to beat nearest neighbor on most instances, got %d to %d", sweepWins, nnWins)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// TabuOptions are the options for tabu search
+type TabuOptions struct {
+	// Iterations is the number of moves to make
+	Iterations int
+	// Tenure is the number of iterations a move stays tabu
+	Tenure int
+	// Seed seeds the random initial tour
+	Seed int64
+	// InitMethod is the InitialTour method of the initial tour
+	InitMethod string
+	// Tour is the starting tour, if nil the search starts from the
+	// InitialTour of InitMethod
+	Tour []int
+	// DiversificationWeight scales the edge frequency penalty applied once
+	// the search starts revisiting solutions
+	DiversificationWeight float64
+	// OnImprove is called with the starting tour and each better tour found,
+	// if it isn't nil
+	OnImprove func(Tour) `json:"-"`
+}
+
+// tabuSearch is the state of a tabu search
+type tabuSearch struct {
+	dist   []float64
+	size   int
+	tenure int
+	// tabu maps a pair of cities to the iteration the pair stops being tabu
+	tabu  map[[2]int]int
+	route []int
+	cost  float64
+	best  Tour
+	// weight is the diversification weight
+	weight float64
+	// frequency counts how many iterations each edge has been in the route
+	frequency map[[2]int]int
+	// visited counts how many times each route has been visited
+	visited map[string]int
+	// cycling is true if the current route has been visited before
+	cycling bool
+}
+
+// newTabuSearch creates a tabu search starting from route
+func newTabuSearch(dist []float64, size int, route []int, tenure int) *tabuSearch {
+	cost := TourCost(dist, size, route)
+	return &tabuSearch{
+		dist:   dist,
+		size:   size,
+		tenure: tenure,
+		tabu:   make(map[[2]int]int),
+		route:  route,
+		cost:   cost,
+		best: Tour{
+			Cost:  cost,
+			Route: append([]int{}, route...),
+		},
+		frequency: make(map[[2]int]int),
+		visited:   make(map[string]int),
+	}
+}
+
+// remember updates the long term memory with the current route
+func (t *tabuSearch) remember() {
+	last := t.route[0]
+	for _, node := range t.route[1:] {
+		t.frequency[pair(last, node)]++
+		last = node
+	}
+	key := fmt.Sprint(t.route)
+	t.visited[key]++
+	t.cycling = t.visited[key] > 1
+}
+
+// penalty is the diversification penalty of reversing the route between i and j
+func (t *tabuSearch) penalty(i, j int) float64 {
+	if !t.cycling || t.weight == 0 {
+		return 0
+	}
+	added := t.frequency[pair(t.route[i-1], t.route[j])] + t.frequency[pair(t.route[i], t.route[j+1])]
+	return t.weight * float64(added)
+}
+
+// pair returns the tabu attribute of reversing the route between a and b
+func pair(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// reverse reverses route[i:j+1] in place
+func reverse(route []int, i, j int) {
+	for i < j {
+		route[i], route[j] = route[j], route[i]
+		i++
+		j--
+	}
+}
+
+// step makes the best admissible 2-opt move, returning false if there is none
+func (t *tabuSearch) step(iteration int) bool {
+	bestScore, bestCost, bestI, bestJ, aspiration := math.MaxFloat64, 0.0, 0, 0, false
+	for i := 1; i < t.size-1; i++ {
+		for j := i + 1; j < t.size; j++ {
+			reverse(t.route, i, j)
+			currentCost := TourCost(t.dist, t.size, t.route)
+			reverse(t.route, i, j)
+			if currentCost < t.best.Cost {
+				if currentCost < bestScore {
+					bestScore, bestCost, bestI, bestJ = currentCost, currentCost, i, j
+					aspiration = t.tabu[pair(t.route[i], t.route[j])] > iteration
+				}
+				continue
+			}
+			score := currentCost + t.penalty(i, j)
+			if score >= bestScore || t.tabu[pair(t.route[i], t.route[j])] > iteration {
+				continue
+			}
+			bestScore, bestCost, bestI, bestJ, aspiration = score, currentCost, i, j, false
+		}
+	}
+	if bestI == 0 {
+		return false
+	}
+	t.tabu[pair(t.route[bestI], t.route[bestJ])] = iteration + 1 + t.tenure
+	reverse(t.route, bestI, bestJ)
+	t.cost = bestCost
+	if aspiration {
+		t.best.AspirationActivations++
+	}
+	if t.cost < t.best.Cost {
+		t.best.Cost = t.cost
+		t.best.Route = append(t.best.Route[:0], t.route...)
+	}
+	t.remember()
+	return true
+}
+
+// TabuSearch uses tabu search to solve the traveling salesman problem
+func TabuSearch(dist []float64, size int, opts TabuOptions) Tour {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	route := append([]int{}, opts.Tour...)
+	if opts.Tour == nil {
+		route = InitialTour(dist, size, opts.InitMethod, rng).Route
+	}
+	t := newTabuSearch(dist, size, route, opts.Tenure)
+	t.weight = opts.DiversificationWeight
+	t.remember()
+	improve := func() {
+		if opts.OnImprove != nil {
+			opts.OnImprove(Tour{Cost: t.best.Cost, Route: append([]int{}, t.best.Route...)})
+		}
+	}
+	improve()
+	for i := 0; i < opts.Iterations; i++ {
+		cost := t.best.Cost
+		if !t.step(i) {
+			break
+		}
+		if t.best.Cost < cost {
+			improve()
+		}
+	}
+	return t.best
+}
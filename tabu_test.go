@@ -0,0 +1,64 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTabuAspiration(t *testing.T) {
+	ts := newTabuSearch(canonical, 4, []int{0, 2, 1, 3, 0}, 100)
+	// the move to the optimal tour is tabu
+	ts.tabu[pair(2, 1)] = 100
+	if !ts.step(0) {
+		t.Fatal("Expected a move to be made")
+	}
+	if ts.best.Cost != 97 {
+		t.Errorf("Expected aspiration to find cost 97, got %f", ts.best.Cost)
+	}
+	if ts.best.AspirationActivations != 1 {
+		t.Errorf("Expected 1 aspiration activation, got %d", ts.best.AspirationActivations)
+	}
+}
+
+func TestTabuSearch(t *testing.T) {
+	optimal, _ := Search(canonical)
+	for seed := int64(1); seed <= 8; seed++ {
+		tour := TabuSearch(canonical, 4, TabuOptions{
+			Iterations: 32,
+			Tenure:     2,
+			Seed:       seed,
+		})
+		if tour.Cost != optimal {
+			t.Errorf("Expected cost %f for seed %d, got %f", optimal, seed, tour.Cost)
+		}
+		if cost := TourCost(canonical, 4, tour.Route); cost != tour.Cost {
+			t.Errorf("Expected route cost %f, got %f", tour.Cost, cost)
+		}
+	}
+}
+
+func TestTabuDiversification(t *testing.T) {
+	unique := func(weight float64) int {
+		rng := rand.New(rand.NewSource(1))
+		dist := randomInstance(rng, 10)
+		route := append(rng.Perm(10), 0)
+		route[10] = route[0]
+		ts := newTabuSearch(dist, 10, route, 3)
+		ts.weight = weight
+		ts.remember()
+		for i := 0; i < 1000; i++ {
+			if !ts.step(i) {
+				break
+			}
+		}
+		return len(ts.visited)
+	}
+	without, with := unique(0), unique(1)
+	if with <= without {
+		t.Errorf("Expected diversification to visit more than %d solutions, got %d", without, with)
+	}
+}
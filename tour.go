@@ -0,0 +1,360 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tour is a solution to the traveling salesman problem
+type Tour struct {
+	// Cost is the total cost of the tour
+	Cost float64
+	// Route is the sequence of cities, the first city is repeated at the end
+	Route []int
+	// AspirationActivations is the number of times a tabu move was accepted
+	// because it improved on the best tour
+	AspirationActivations int
+	// Iterations is the number of iterations the search ran
+	Iterations int
+	// MaxEdge is the cost of the most expensive edge, set by
+	// MultiObjectiveTSP
+	MaxEdge float64
+	// Elapsed is the wall clock time the solver ran, set by Solver.Solve
+	Elapsed time.Duration
+}
+
+// String formats the cost, the route and the elapsed time of the tour, such
+// as Tour{Cost:97.00, Route:[0→1→2→3→0], Elapsed:12µs}
+func (t Tour) String() string {
+	cities := make([]string, len(t.Route))
+	for i, city := range t.Route {
+		cities[i] = strconv.Itoa(city)
+	}
+	return fmt.Sprintf("Tour{Cost:%.2f, Route:[%s], Elapsed:%v}",
+		t.Cost, strings.Join(cities, "→"), t.Elapsed.Round(time.Microsecond))
+}
+
+// goFloat formats f as a Go expression
+func goFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// GoString formats the tour as a Go composite literal for %#v
+func (t Tour) GoString() string {
+	route := "[]int(nil)"
+	if t.Route != nil {
+		cities := make([]string, len(t.Route))
+		for i, city := range t.Route {
+			cities[i] = strconv.Itoa(city)
+		}
+		route = "[]int{" + strings.Join(cities, ", ") + "}"
+	}
+	return fmt.Sprintf("Tour{Cost: %s, Route: %s, AspirationActivations: %d, Iterations: %d, MaxEdge: %s, Elapsed: %d}",
+		goFloat(t.Cost), route, t.AspirationActivations, t.Iterations, goFloat(t.MaxEdge), int64(t.Elapsed))
+}
+
+// TourCost computes the cost of a closed route
+func TourCost(dist []float64, size int, route []int) float64 {
+	total := 0.0
+	last := route[0]
+	for _, node := range route[1:] {
+		total += dist[last*size+node]
+		last = node
+	}
+	return total
+}
+
+// TourLength is the number of edges of a closed route, which is the number of
+// cities for a valid tour
+func TourLength(tour []int) int {
+	return len(tour) - 1
+}
+
+// AverageCostPerEdge is the cost of a closed route divided by its number of
+// edges
+func AverageCostPerEdge(dist []float64, size int, tour []int) float64 {
+	return TourCost(dist, size, tour) / float64(TourLength(tour))
+}
+
+// ValidateTour checks that route visits every city exactly once and returns
+// to the first city
+func ValidateTour(route []int, size int) error {
+	if len(route) != size+1 {
+		return fmt.Errorf("route has %d cities, expected %d", len(route), size+1)
+	}
+	if route[0] != route[size] {
+		return fmt.Errorf("route starts at %d but ends at %d", route[0], route[size])
+	}
+	visited := make([]bool, size)
+	for _, node := range route[:size] {
+		if node < 0 || node >= size {
+			return fmt.Errorf("city %d is out of range", node)
+		}
+		if visited[node] {
+			return fmt.Errorf("city %d is visited more than once", node)
+		}
+		visited[node] = true
+	}
+	return nil
+}
+
+// VerifyTour checks that the route of the tour is valid and that its claimed
+// cost is within 1e-9 of the actual cost, the error reports the difference
+func VerifyTour(dist []float64, size int, t Tour) error {
+	if err := ValidateTour(t.Route, size); err != nil {
+		return fmt.Errorf("invalid tour: %v", err)
+	}
+	if actual := TourCost(dist, size, t.Route); math.Abs(actual-t.Cost) > 1e-9 {
+		return fmt.Errorf("claimed cost %v, actual cost %v, difference %v", t.Cost, actual, t.Cost-actual)
+	}
+	return nil
+}
+
+// nearestNeighbor builds a tour from start by always moving to the closest
+// unvisited city
+func nearestNeighbor(dist []float64, size, start int) Tour {
+	return explainedNearestNeighbor(dist, size, start, nil)
+}
+
+// explainedNearestNeighbor is nearestNeighbor explaining each move with the
+// explainer
+func explainedNearestNeighbor(dist []float64, size, start int, e *explainer) Tour {
+	visited := make([]bool, size)
+	state := start
+	visited[state] = true
+	route := make([]int, 0, size+1)
+	route = append(route, state)
+	for i := 0; i < size-1; i++ {
+		min, k := math.MaxFloat64, 0
+		for j := 0; j < size; j++ {
+			if visited[j] {
+				continue
+			}
+			if v := dist[state*size+j]; v < min {
+				min, k = v, j
+			}
+		}
+		if e.enabled() {
+			explainNearest(e, dist, size, visited, state, k)
+		}
+		state = k
+		visited[state] = true
+		route = append(route, state)
+	}
+	route = append(route, start)
+	return Tour{
+		Cost:  TourCost(dist, size, route),
+		Route: route,
+	}
+}
+
+// NearestNeighborK builds the nearest neighbor tours from k different
+// random starting cities and returns the best, all of the cities are tried if
+// k is at least size and one if k is less than one
+func NearestNeighborK(dist []float64, size, k int, rng *rand.Rand) Tour {
+	if k > size {
+		k = size
+	} else if k < 1 {
+		k = 1
+	}
+	best := Tour{Cost: math.MaxFloat64}
+	for _, start := range rng.Perm(size)[:k] {
+		if tour := nearestNeighbor(dist, size, start); tour.Cost < best.Cost {
+			best = tour
+		}
+	}
+	return best
+}
+
+// explainNearest explains moving from city state to the closest unvisited
+// city k, compared to the next closest unvisited city
+func explainNearest(e *explainer, dist []float64, size int, visited []bool, state, k int) {
+	next := -1
+	for j := 0; j < size; j++ {
+		if visited[j] || j == k {
+			continue
+		}
+		if next == -1 || dist[state*size+j] < dist[state*size+next] {
+			next = j
+		}
+	}
+	if next == -1 {
+		e.explain("from city %d, chose city %d (distance %.1f), the last unvisited city",
+			state, k, dist[state*size+k])
+		return
+	}
+	e.explain("from city %d, chose city %d (distance %.1f) over city %d (distance %.1f)",
+		state, k, dist[state*size+k], next, dist[state*size+next])
+}
+
+// TourEdgeDistance counts the undirected edges of route a that are not in
+// route b
+func TourEdgeDistance(a, b []int) int {
+	edges := make(map[[2]int]int, len(b))
+	for i := 1; i < len(b); i++ {
+		edges[pair(b[i-1], b[i])]++
+	}
+	distance := 0
+	for i := 1; i < len(a); i++ {
+		e := pair(a[i-1], a[i])
+		if edges[e] > 0 {
+			edges[e]--
+			continue
+		}
+		distance++
+	}
+	return distance
+}
+
+// Edges returns the edges of the closed route in order, including the edge
+// back to the first city. A route of fewer than 2 cities has no edges.
+func (t Tour) Edges(dist []float64, size int) []WeightedEdge {
+	if len(t.Route) < 2 {
+		return nil
+	}
+	edges := make([]WeightedEdge, 0, len(t.Route)-1)
+	for i := 1; i < len(t.Route); i++ {
+		from, to := t.Route[i-1], t.Route[i]
+		edges = append(edges, WeightedEdge{From: from, To: to, Weight: dist[from*size+to]})
+	}
+	return edges
+}
+
+// TourComparison is the difference between two tours
+type TourComparison struct {
+	// EdgeDistance is the number of edges of the first tour that aren't in
+	// the second, see TourEdgeDistance
+	EdgeDistance int
+	// CostDiff is the cost of the second tour minus the cost of the first
+	CostDiff float64
+	// CommonEdges are the edges of the first tour that are in the second
+	CommonEdges []WeightedEdge
+	// AddedEdges are the edges of the second tour that aren't in the first
+	AddedEdges []WeightedEdge
+	// RemovedEdges are the edges of the first tour that aren't in the second
+	RemovedEdges []WeightedEdge
+}
+
+// CompareTours compares tour b to tour a, edges are matched in either
+// direction like TourEdgeDistance
+func CompareTours(a, b Tour, dist []float64, size int) TourComparison {
+	comparison := TourComparison{CostDiff: b.Cost - a.Cost}
+	// split puts the edges of x in common if they are in y and in other if
+	// they aren't
+	split := func(x, y Tour, common, other *[]WeightedEdge) {
+		edges := make(map[[2]int]int, len(y.Route))
+		for i := 1; i < len(y.Route); i++ {
+			edges[pair(y.Route[i-1], y.Route[i])]++
+		}
+		for _, edge := range x.Edges(dist, size) {
+			e := pair(edge.From, edge.To)
+			if edges[e] > 0 {
+				edges[e]--
+				if common != nil {
+					*common = append(*common, edge)
+				}
+				continue
+			}
+			*other = append(*other, edge)
+		}
+	}
+	split(a, b, &comparison.CommonEdges, &comparison.RemovedEdges)
+	split(b, a, nil, &comparison.AddedEdges)
+	comparison.EdgeDistance = len(comparison.RemovedEdges)
+	return comparison
+}
+
+// Reverse returns the tour visiting the cities in the opposite order from the
+// same first city. The cost is kept, which is only correct for symmetric
+// instances.
+func (t Tour) Reverse() Tour {
+	reversed := t
+	reversed.Route = append([]int{}, t.Route...)
+	reverse(reversed.Route, 1, len(reversed.Route)-2)
+	return reversed
+}
+
+// Rotate returns the tour starting and ending at the city start, or an error
+// if start isn't in the route
+func (t Tour) Rotate(start int) (Tour, error) {
+	route := rotate(t.Route, start)
+	if route == nil {
+		return Tour{}, fmt.Errorf("city %d is not in the route", start)
+	}
+	rotated := t
+	rotated.Route = route
+	return rotated, nil
+}
+
+// BestOf returns the first of the tours with the lowest cost, it panics if
+// there are no tours
+func BestOf(tours ...Tour) Tour {
+	if len(tours) == 0 {
+		panic("BestOf requires at least one tour")
+	}
+	best := tours[0]
+	for _, tour := range tours[1:] {
+		if tour.Cost < best.Cost {
+			best = tour
+		}
+	}
+	return best
+}
+
+// WorstOf returns the first of the tours with the highest cost, it panics if
+// there are no tours
+func WorstOf(tours ...Tour) Tour {
+	if len(tours) == 0 {
+		panic("WorstOf requires at least one tour")
+	}
+	worst := tours[0]
+	for _, tour := range tours[1:] {
+		if tour.Cost > worst.Cost {
+			worst = tour
+		}
+	}
+	return worst
+}
+
+// MeanCost returns the mean cost of the tours, it panics if there are no tours
+func MeanCost(tours ...Tour) float64 {
+	if len(tours) == 0 {
+		panic("MeanCost requires at least one tour")
+	}
+	total := 0.0
+	for _, tour := range tours {
+		total += tour.Cost
+	}
+	return total / float64(len(tours))
+}
+
+// rotate rotates the closed route so it starts and ends at start, returning
+// nil if start isn't in the route
+func rotate(route []int, start int) []int {
+	size := len(route) - 1
+	for i, city := range route[:size] {
+		if city == start {
+			rotated := make([]int, 0, size+1)
+			rotated = append(rotated, route[i:size]...)
+			rotated = append(rotated, route[:i]...)
+			return append(rotated, start)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// randomInstance generates a random symmetric instance
+func randomInstance(rng *rand.Rand, size int) []float64 {
+	dist := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := i + 1; j < size; j++ {
+			value := float64(rng.Intn(100) + 1)
+			dist[i*size+j] = value
+			dist[j*size+i] = value
+		}
+	}
+	return dist
+}
+
+// euclideanInstance generates a random instance from points in the unit square
+func euclideanInstance(rng *rand.Rand, size int) []float64 {
+	x, y := make([]float64, size), make([]float64, size)
+	for i := range x {
+		x[i], y[i] = rng.Float64(), rng.Float64()
+	}
+	dist := make([]float64, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			dist[i*size+j] = math.Hypot(x[i]-x[j], y[i]-y[j])
+		}
+	}
+	return dist
+}
+
+func TestTourCost(t *testing.T) {
+	cost := TourCost(canonical, 4, []int{0, 1, 2, 3, 0})
+	if cost != 97 {
+		t.Errorf("Expected cost of 97, got %f", cost)
+	}
+}
+
+func TestTourLength(t *testing.T) {
+	route := []int{0, 1, 2, 3, 0}
+	if length := TourLength(route); length != Size {
+		t.Errorf("Expected length %d, got %d", Size, length)
+	}
+	if average := AverageCostPerEdge(canonical, Size, route); average != 97.0/4 {
+		t.Errorf("Expected an average cost of %f, got %f", 97.0/4, average)
+	}
+}
+
+func TestTourEdgeDistance(t *testing.T) {
+	a, b := []int{0, 1, 2, 3, 4, 0}, []int{0, 2, 1, 3, 4, 0}
+	if d := TourEdgeDistance(a, a); d != 0 {
+		t.Errorf("Expected distance 0, got %d", d)
+	}
+	if d := TourEdgeDistance(a, []int{0, 4, 3, 2, 1, 0}); d != 0 {
+		t.Errorf("Expected distance 0 to the reverse, got %d", d)
+	}
+	if d := TourEdgeDistance(a, b); d != 2 {
+		t.Errorf("Expected distance 2, got %d", d)
+	}
+}
+
+func TestVerifyTour(t *testing.T) {
+	if err := VerifyTour(canonical, Size, Tour{Cost: 97, Route: []int{0, 1, 2, 3, 0}}); err != nil {
+		t.Error(err)
+	}
+	if err := VerifyTour(canonical, Size, Tour{Cost: 96, Route: []int{0, 1, 2, 3, 0}}); err == nil {
+		t.Error("Expected an error for the wrong cost")
+	}
+	if err := VerifyTour(canonical, Size, Tour{Cost: 97, Route: []int{0, 1, 2, 4, 0}}); err == nil {
+		t.Error("Expected an error for an invalid route")
+	}
+}
+
+func TestTourReverse(t *testing.T) {
+	dist := euclideanInstance(rand.New(rand.NewSource(1)), 8)
+	tour := nearestNeighbor(dist, 8, 3)
+	reversed := tour.Reverse()
+	if reversed.Route[0] != 3 || reversed.Route[1] != tour.Route[7] || reversed.Route[8] != 3 {
+		t.Errorf("Expected %v reversed from city 3, got %v", tour.Route, reversed.Route)
+	}
+	if cost := TourCost(dist, 8, reversed.Route); math.Abs(cost-tour.Cost) > 1e-9 || reversed.Cost != tour.Cost {
+		t.Errorf("Expected cost %f, got %f", tour.Cost, cost)
+	}
+	if twice := reversed.Reverse(); !equal(twice.Route, tour.Route) {
+		t.Errorf("Expected %v, got %v", tour.Route, twice.Route)
+	}
+}
+
+func TestTourRotate(t *testing.T) {
+	tour := Tour{Cost: 97, Route: []int{0, 1, 2, 3, 0}}
+	tests := []struct {
+		start int
+		route []int
+	}{
+		{0, []int{0, 1, 2, 3, 0}},
+		{2, []int{2, 3, 0, 1, 2}},
+		{3, []int{3, 0, 1, 2, 3}},
+	}
+	for _, test := range tests {
+		rotated, err := tour.Rotate(test.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equal(rotated.Route, test.route) || rotated.Cost != tour.Cost {
+			t.Errorf("Expected %v from %d, got %v", test.route, test.start, rotated.Route)
+		}
+	}
+	if _, err := tour.Rotate(4); err == nil {
+		t.Error("Expected an error for a city that isn't in the route")
+	}
+}
+
+func TestBestOf(t *testing.T) {
+	tours := []Tour{
+		{Cost: 5, Route: []int{0}},
+		{Cost: 3, Route: []int{1}},
+		{Cost: 9, Route: []int{2}},
+		{Cost: 3, Route: []int{3}},
+		{Cost: 9, Route: []int{4}},
+	}
+	if best := BestOf(tours...); best.Route[0] != 1 {
+		t.Errorf("Expected the first tour with cost 3, got %v", best)
+	}
+	if worst := WorstOf(tours...); worst.Route[0] != 2 {
+		t.Errorf("Expected the first tour with cost 9, got %v", worst)
+	}
+	if mean := MeanCost(tours...); mean != 5.8 {
+		t.Errorf("Expected a mean cost of 5.8, got %f", mean)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic without tours")
+		}
+	}()
+	BestOf()
+}
+
+func TestTourEdges(t *testing.T) {
+	tour := Tour{Cost: 97, Route: []int{0, 1, 2, 3, 0}}
+	edges := tour.Edges(canonical, Size)
+	expected := []WeightedEdge{
+		{From: 0, To: 1, Weight: 20},
+		{From: 1, To: 2, Weight: 30},
+		{From: 2, To: 3, Weight: 12},
+		{From: 3, To: 0, Weight: 35},
+	}
+	if len(edges) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, edges)
+	}
+	total := 0.0
+	for i, edge := range edges {
+		if edge != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], edge)
+		}
+		total += edge.Weight
+	}
+	if total != tour.Cost {
+		t.Errorf("Expected the edges to cost %f, got %f", tour.Cost, total)
+	}
+	if edges := (Tour{}).Edges(canonical, Size); edges != nil {
+		t.Errorf("Expected no edges for an empty tour, got %v", edges)
+	}
+}
+
+func TestCompareTours(t *testing.T) {
+	a := Tour{Cost: 97, Route: []int{0, 1, 2, 3, 0}}
+	same := CompareTours(a, a, canonical, Size)
+	if same.EdgeDistance != 0 || same.CostDiff != 0 || len(same.CommonEdges) != 4 {
+		t.Errorf("Expected a tour to match itself, got %+v", same)
+	}
+	if len(same.AddedEdges) != 0 || len(same.RemovedEdges) != 0 {
+		t.Errorf("Expected no added or removed edges, got %+v", same)
+	}
+	if reversed := CompareTours(a, a.Reverse(), canonical, Size); reversed.EdgeDistance != 0 {
+		t.Errorf("Expected the reversed tour to have the same edges, got %+v", reversed)
+	}
+
+	route := []int{0, 2, 1, 3, 0}
+	b := Tour{Cost: TourCost(canonical, Size, route), Route: route}
+	comparison := CompareTours(a, b, canonical, Size)
+	if comparison.EdgeDistance != TourEdgeDistance(a.Route, b.Route) {
+		t.Errorf("Expected an edge distance of %d, got %d", TourEdgeDistance(a.Route, b.Route), comparison.EdgeDistance)
+	}
+	if comparison.CostDiff != b.Cost-a.Cost {
+		t.Errorf("Expected a cost difference of %f, got %f", b.Cost-a.Cost, comparison.CostDiff)
+	}
+	expected := map[string][]WeightedEdge{
+		"common":  {{From: 1, To: 2, Weight: 30}, {From: 3, To: 0, Weight: 35}},
+		"added":   {{From: 0, To: 2, Weight: canonical[2]}, {From: 1, To: 3, Weight: canonical[1*Size+3]}},
+		"removed": {{From: 0, To: 1, Weight: 20}, {From: 2, To: 3, Weight: 12}},
+	}
+	for name, edges := range map[string][]WeightedEdge{
+		"common":  comparison.CommonEdges,
+		"added":   comparison.AddedEdges,
+		"removed": comparison.RemovedEdges,
+	} {
+		if fmt.Sprint(edges) != fmt.Sprint(expected[name]) {
+			t.Errorf("Expected %s edges %v, got %v", name, expected[name], edges)
+		}
+	}
+}
+
+func TestTourString(t *testing.T) {
+	tour := Tour{Cost: 87, Route: []int{0, 2, 3, 1, 0}, Elapsed: 12 * time.Millisecond}
+	if s, expected := fmt.Sprint(tour), "Tour{Cost:87.00, Route:[0→2→3→1→0], Elapsed:12ms}"; s != expected {
+		t.Errorf("Expected %s, got %s", expected, s)
+	}
+	for _, tour := range []Tour{tour, {}, {Cost: math.Inf(1), MaxEdge: math.NaN(), Iterations: 3}} {
+		s := fmt.Sprintf("%#v", tour)
+		if s == "" {
+			t.Fatal("Expected a Go literal, got an empty string")
+		}
+		expression, err := parser.ParseExpr(s)
+		if err != nil {
+			t.Fatalf("Expected %s to parse: %v", s, err)
+		}
+		if literal, ok := expression.(*ast.CompositeLit); !ok || len(literal.Elts) != 6 {
+			t.Errorf("Expected a composite literal with 6 fields, got %s", s)
+		}
+	}
+}
+
+func TestNearestNeighborK(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := randomInstance(rng, 12)
+	all := math.MaxFloat64
+	for start := 0; start < 12; start++ {
+		all = math.Min(all, nearestNeighbor(dist, 12, start).Cost)
+	}
+	for _, k := range []int{0, 1, 4, 12, 20} {
+		tour := NearestNeighborK(dist, 12, k, rng)
+		if err := VerifyTour(dist, 12, tour); err != nil {
+			t.Fatal(err)
+		}
+		if tour.Cost < all || (k >= 12 && tour.Cost != all) {
+			t.Errorf("Expected k=%d to find at best %f, got %f", k, all, tour.Cost)
+		}
+	}
+}
+
+// nearestNeighborK100 builds nearest neighbor tours from k starts on 100 city
+// euclidean instances, reporting the mean cost
+func nearestNeighborK100(b *testing.B, k int) {
+	rng := rand.New(rand.NewSource(1))
+	instances := make([][]float64, 8)
+	for i := range instances {
+		instances[i] = euclideanInstance(rng, 100)
+	}
+	b.ResetTimer()
+	cost := 0.0
+	for n := 0; n < b.N; n++ {
+		dist := instances[n%len(instances)]
+		cost += NearestNeighborK(dist, 100, k, rng).Cost
+	}
+	b.ReportMetric(cost/float64(b.N), "cost/instance")
+}
+
+func BenchmarkNearestNeighborK10(b *testing.B) {
+	nearestNeighborK100(b, 10)
+}
+
+func BenchmarkNearestNeighborAll(b *testing.B) {
+	nearestNeighborK100(b, 100)
+}
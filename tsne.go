@@ -0,0 +1,257 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+const (
+	// TSNEIterations is the number of gradient descent iterations of t-SNE
+	TSNEIterations = 1000
+	// tsneExaggeration is the early exaggeration of the input affinities
+	tsneExaggeration = 4
+	// tsneExaggerationIterations is the number of exaggerated iterations
+	tsneExaggerationIterations = 100
+	// tsneLearningRate is the learning rate of the gradient descent
+	tsneLearningRate = 200
+)
+
+// affinities computes the symmetric input affinities of t-SNE, the bandwidth
+// of each row is found with a binary search so that the conditional
+// distribution has the given perplexity
+func affinities(data *mat.Dense, perplexity float64) []float64 {
+	n, d := data.Dims()
+	distances := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for k := 0; k < d; k++ {
+				x := data.At(i, k) - data.At(j, k)
+				sum += x * x
+			}
+			distances[i*n+j] = sum
+		}
+	}
+
+	target := math.Log(perplexity)
+	p := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		row := p[i*n : (i+1)*n]
+		beta, min, max := 1.0, math.Inf(-1), math.Inf(1)
+		for k := 0; k < 64; k++ {
+			sum, weighted := 0.0, 0.0
+			for j := range row {
+				if i == j {
+					row[j] = 0
+					continue
+				}
+				row[j] = math.Exp(-distances[i*n+j] * beta)
+				sum += row[j]
+				weighted += distances[i*n+j] * row[j]
+			}
+			if sum == 0 {
+				sum = math.SmallestNonzeroFloat64
+			}
+			entropy := math.Log(sum) + beta*weighted/sum
+			for j := range row {
+				row[j] /= sum
+			}
+			diff := entropy - target
+			if math.Abs(diff) < 1e-5 {
+				break
+			}
+			if diff > 0 {
+				min = beta
+				if math.IsInf(max, 1) {
+					beta *= 2
+				} else {
+					beta = (beta + max) / 2
+				}
+			} else {
+				max = beta
+				if math.IsInf(min, -1) {
+					beta /= 2
+				} else {
+					beta = (beta + min) / 2
+				}
+			}
+		}
+	}
+
+	symmetric := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			symmetric[i*n+j] = math.Max((p[i*n+j]+p[j*n+i])/float64(2*n), 1e-12)
+		}
+	}
+	return symmetric
+}
+
+// TSNE embeds the rows of data into two dimensions with t-distributed
+// stochastic neighbor embedding
+func TSNE(data *mat.Dense, perplexity float64, iterations int, rng *rand.Rand) *mat.Dense {
+	n, _ := data.Dims()
+	p := affinities(data, perplexity)
+	for i := range p {
+		p[i] *= tsneExaggeration
+	}
+
+	const dims = 2
+	y := make([]float64, n*dims)
+	for i := range y {
+		y[i] = rng.NormFloat64() * 1e-4
+	}
+	velocity := make([]float64, n*dims)
+	gains := make([]float64, n*dims)
+	for i := range gains {
+		gains[i] = 1
+	}
+	num := make([]float64, n*n)
+	gradient := make([]float64, n*dims)
+	for iteration := 0; iteration < iterations; iteration++ {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i == j {
+					num[i*n+j] = 0
+					continue
+				}
+				distance := 0.0
+				for k := 0; k < dims; k++ {
+					x := y[i*dims+k] - y[j*dims+k]
+					distance += x * x
+				}
+				num[i*n+j] = 1 / (1 + distance)
+				sum += num[i*n+j]
+			}
+		}
+
+		for i := range gradient {
+			gradient[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				q := math.Max(num[i*n+j]/sum, 1e-12)
+				scale := 4 * (p[i*n+j] - q) * num[i*n+j]
+				for k := 0; k < dims; k++ {
+					gradient[i*dims+k] += scale * (y[i*dims+k] - y[j*dims+k])
+				}
+			}
+		}
+
+		momentum := .5
+		if iteration >= 250 {
+			momentum = .8
+		}
+		for i := range y {
+			if (gradient[i] > 0) != (velocity[i] > 0) {
+				gains[i] += .2
+			} else {
+				gains[i] *= .8
+			}
+			gains[i] = math.Max(gains[i], .01)
+			velocity[i] = momentum*velocity[i] - tsneLearningRate*gains[i]*gradient[i]
+			y[i] += velocity[i]
+		}
+
+		for k := 0; k < dims; k++ {
+			mean := 0.0
+			for i := 0; i < n; i++ {
+				mean += y[i*dims+k]
+			}
+			mean /= float64(n)
+			for i := 0; i < n; i++ {
+				y[i*dims+k] -= mean
+			}
+		}
+
+		if iteration == tsneExaggerationIterations {
+			for i := range p {
+				p[i] /= tsneExaggeration
+			}
+		}
+	}
+	return mat.NewDense(n, dims, y)
+}
+
+// scatterPlot plots the first two columns of proj as a scatter plot
+func scatterPlot(title string, proj *mat.Dense) (*plot.Plot, error) {
+	r, _ := proj.Dims()
+	points := make(plotter.XYs, 0, r)
+	for i := 0; i < r; i++ {
+		points = append(points, plotter.XY{X: proj.At(i, 0), Y: proj.At(i, 1)})
+	}
+
+	p := plot.New()
+
+	p.Title.Text = title
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	scatter, err := plotter.NewScatter(points)
+	if err != nil {
+		return nil, err
+	}
+	scatter.GlyphStyle.Radius = vg.Length(3)
+	scatter.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(scatter)
+	return p, nil
+}
+
+// ReductionTSNE reduces the matrix with t-SNE and plots the result alongside
+// the PCA reduction
+func ReductionTSNE(name string, ranks *mat.Dense, perplexity float64) {
+	proj, err := project(ranks)
+	if err != nil {
+		panic(err)
+	}
+	embedding := TSNE(ranks, perplexity, TSNEIterations, rand.New(rand.NewSource(1)))
+
+	pca, err := scatterPlot("PCA", proj)
+	if err != nil {
+		panic(err)
+	}
+	tsne, err := scatterPlot("t-SNE", embedding)
+	if err != nil {
+		panic(err)
+	}
+
+	img := vgimg.New(16*vg.Inch, 8*vg.Inch)
+	dc := draw.New(img)
+	canvases := plot.Align([][]*plot.Plot{{pca, tsne}}, draw.Tiles{Rows: 1, Cols: 2}, dc)
+	pca.Draw(canvases[0][0])
+	tsne.Draw(canvases[0][1])
+
+	output, err := os.Create(fmt.Sprintf("%s.png", name))
+	if err != nil {
+		panic(err)
+	}
+	defer output.Close()
+	_, err = vgimg.PngCanvas{Canvas: img}.WriteTo(output)
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := os.Create(fmt.Sprintf("%s.dat", name))
+	if err != nil {
+		panic(err)
+	}
+	defer data.Close()
+	r, _ := embedding.Dims()
+	for i := 0; i < r; i++ {
+		fmt.Fprintf(data, "%f %f\n", embedding.At(i, 0), embedding.At(i, 1))
+	}
+}
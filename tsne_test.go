@@ -0,0 +1,41 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestTSNE(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranks := mat.NewDense(Size, Size, nil)
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			ranks.Set(i, j, rng.Float64())
+		}
+	}
+	embedding := TSNE(ranks, 2, TSNEIterations, rng)
+	r, c := embedding.Dims()
+	if r != ranks.RawMatrix().Rows || c != 2 {
+		t.Fatalf("Expected %dx2 embedding, got %dx%d", ranks.RawMatrix().Rows, r, c)
+	}
+	for _, v := range embedding.RawMatrix().Data {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			t.Fatalf("Expected finite embedding, got %v", embedding.RawMatrix().Data)
+		}
+	}
+
+	name := filepath.Join(t.TempDir(), "tsne")
+	ReductionTSNE(name, ranks, 2)
+	if _, err := os.Stat(name + ".png"); err != nil {
+		t.Error(err)
+	}
+}
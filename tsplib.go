@@ -0,0 +1,150 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// BenchmarkResult is the result of one solver on one instance of
+// RunTSPLIBBenchmark, the cost and the gap are NaN if the solver didn't run or
+// didn't finish
+type BenchmarkResult struct {
+	Instance, Method string
+	Cost             float64
+	// Optimal is the known optimal cost of the instance, zero if unknown
+	Optimal float64
+	// Gap is the relative gap between the cost and the optimal cost, NaN if
+	// the optimal cost is unknown
+	Gap     float64
+	Elapsed time.Duration
+	// Note is why the solver didn't run or didn't finish
+	Note string
+}
+
+// RunTSPLIBBenchmark runs the named default solvers, or all of them if there
+// are no methods, on each instance file with the time limit. The solvers that
+// only work with Size cities are skipped for other instances. The solvers
+// can't be cancelled, so a solver that doesn't finish in time is left running
+// in the background until it returns and slows the solvers run after it. To
+// limit this a solver that timed out is run after the other solvers on the
+// remaining instances, the results are still in the order of the methods.
+func RunTSPLIBBenchmark(instances []string, methods []string, timeLimit time.Duration) []BenchmarkResult {
+	solvers := DefaultSolvers()
+	if len(methods) == 0 {
+		for name := range solvers {
+			methods = append(methods, name)
+		}
+		sort.Strings(methods)
+	}
+	var results []BenchmarkResult
+	timedOut := make(map[string]bool)
+	for _, path := range instances {
+		instance, err := ReadInstanceFile(path, "")
+		if err != nil {
+			for _, method := range methods {
+				results = append(results, skippedResult(path, method, 0, err.Error()))
+			}
+			continue
+		}
+		sized := SizedSolvers(solvers, instance.Size)
+		order := make([]int, len(methods))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return !timedOut[methods[order[i]]] && timedOut[methods[order[j]]]
+		})
+		instanceResults := make([]BenchmarkResult, len(methods))
+		for _, i := range order {
+			method := methods[i]
+			solver, ok := sized[method]
+			switch {
+			case !ok && solvers[method] != nil:
+				instanceResults[i] = skippedResult(path, method, instance.Optimal, "requires "+strconv.Itoa(Size)+" cities")
+				continue
+			case !ok:
+				instanceResults[i] = skippedResult(path, method, instance.Optimal, "unknown solver")
+				continue
+			}
+			done := make(chan Tour, 1)
+			start := time.Now()
+			go func() {
+				done <- solver.Solve(instance.Dist, instance.Size)
+			}()
+			select {
+			case tour := <-done:
+				result := BenchmarkResult{
+					Instance: path,
+					Method:   method,
+					Cost:     tour.Cost,
+					Optimal:  instance.Optimal,
+					Gap:      math.NaN(),
+					Elapsed:  time.Since(start),
+				}
+				if instance.Optimal > 0 {
+					result.Gap = (tour.Cost - instance.Optimal) / instance.Optimal
+				}
+				instanceResults[i] = result
+			case <-time.After(timeLimit):
+				timedOut[method] = true
+				result := skippedResult(path, method, instance.Optimal, "time limit")
+				result.Elapsed = timeLimit
+				instanceResults[i] = result
+			}
+		}
+		results = append(results, instanceResults...)
+	}
+	return results
+}
+
+// skippedResult is the result of a solver that didn't run or didn't finish
+func skippedResult(instance, method string, optimal float64, note string) BenchmarkResult {
+	return BenchmarkResult{
+		Instance: instance,
+		Method:   method,
+		Cost:     math.NaN(),
+		Optimal:  optimal,
+		Gap:      math.NaN(),
+		Note:     note,
+	}
+}
+
+// WriteBenchmarkResults writes the results as csv, the costs and the gaps that
+// are NaN are written as N/A
+func WriteBenchmarkResults(w io.Writer, results []BenchmarkResult) error {
+	format := func(value float64) string {
+		if math.IsNaN(value) {
+			return "N/A"
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"instance", "method", "cost", "optimal", "gap", "elapsed", "note"})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		err = writer.Write([]string{
+			result.Instance,
+			result.Method,
+			format(result.Cost),
+			format(result.Optimal),
+			format(result.Gap),
+			result.Elapsed.String(),
+			result.Note,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunTSPLIBBenchmark(t *testing.T) {
+	dir := t.TempDir()
+	square := filepath.Join(dir, "square.tsp")
+	err := os.WriteFile(square, []byte("NAME: square\nTYPE: TSP\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EUC_2D\nNODE_COORD_SECTION\n1 0 0\n2 3 0\n3 3 4\n4 0 4\nEOF\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var circle strings.Builder
+	circle.WriteString("NAME: circle\nTYPE: TSP\nDIMENSION: 16\nEDGE_WEIGHT_TYPE: EUC_2D\nNODE_COORD_SECTION\n")
+	for i := 0; i < 16; i++ {
+		angle := 2 * math.Pi * float64(i) / 16
+		fmt.Fprintf(&circle, "%d %f %f\n", i+1, 100*math.Cos(angle), 100*math.Sin(angle))
+	}
+	circle.WriteString("EOF\n")
+	circle16 := filepath.Join(dir, "circle.tsp")
+	if err := os.WriteFile(circle16, []byte(circle.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := RunTSPLIBBenchmark([]string{square, circle16}, []string{"search", "tabu", "unknown"}, time.Minute)
+	if len(results) != 6 {
+		t.Fatalf("Expected 6 results, got %d", len(results))
+	}
+	for _, result := range results {
+		ran := result.Method == "tabu" || result.Method == "search" && result.Instance == square
+		if ran && (math.IsNaN(result.Cost) || result.Note != "") {
+			t.Errorf("Expected %s to solve %s, got %v", result.Method, result.Instance, result)
+		}
+		if !ran && (!math.IsNaN(result.Cost) || result.Note == "") {
+			t.Errorf("Expected %s to skip %s, got %v", result.Method, result.Instance, result)
+		}
+	}
+	if results[0].Cost != 14 || results[1].Cost != 14 {
+		t.Errorf("Expected the square to cost 14, got %f and %f", results[0].Cost, results[1].Cost)
+	}
+
+	timeout := RunTSPLIBBenchmark([]string{circle16}, []string{"mcts"}, time.Nanosecond)
+	if len(timeout) != 1 || timeout[0].Note != "time limit" || timeout[0].Elapsed != time.Nanosecond {
+		t.Errorf("Expected mcts to hit the time limit, got %v", timeout)
+	}
+	// the solvers that timed out run last but keep their place in the results
+	methods := []string{"mcts", "tabu"}
+	timeout = RunTSPLIBBenchmark([]string{square, circle16}, methods, time.Nanosecond)
+	if len(timeout) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(timeout))
+	}
+	for i, result := range timeout {
+		if result.Method != methods[i%2] {
+			t.Errorf("Expected result %d to be %s, got %s", i, methods[i%2], result.Method)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteBenchmarkResults(&buffer, results); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 7 || !strings.Contains(lines[4], "search,N/A,0,N/A") {
+		t.Errorf("Expected a header and 6 rows with N/A for the skipped search, got\n%s", buffer.String())
+	}
+}
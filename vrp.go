@@ -0,0 +1,137 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "fmt"
+
+// VRPInstance is a capacitated vehicle routing problem
+type VRPInstance struct {
+	// Dist is the distance matrix
+	Dist []float64
+	// Size is the number of cities including the depot
+	Size int
+	// Depot is the city every route starts and ends at
+	Depot int
+	// Demands is the demand of each city
+	Demands []float64
+	// Capacity is the capacity of each vehicle
+	Capacity float64
+	// Vehicles is the number of vehicles
+	Vehicles int
+}
+
+// load computes the total demand of a route
+func (inst VRPInstance) load(route []int) float64 {
+	total := 0.0
+	for _, city := range route[1 : len(route)-1] {
+		total += inst.Demands[city]
+	}
+	return total
+}
+
+// routesCost computes the total cost of the routes
+func (inst VRPInstance) routesCost(routes []Tour) float64 {
+	total := 0.0
+	for _, route := range routes {
+		total += route.Cost
+	}
+	return total
+}
+
+// SolveVRP solves the vehicle routing problem by filling each vehicle with
+// nearest neighbors until it reaches capacity, improving each route with 2-opt
+// and then moving cities between routes. If the vehicles can't serve every
+// city, because they are full or the demand of a city exceeds the capacity, the
+// routes are returned with an error listing the unserved cities.
+func SolveVRP(inst VRPInstance) ([]Tour, error) {
+	visited := make([]bool, inst.Size)
+	visited[inst.Depot] = true
+	routes := make([]Tour, 0, inst.Vehicles)
+	for v := 0; v < inst.Vehicles; v++ {
+		route, load, state := []int{inst.Depot}, 0.0, inst.Depot
+		for {
+			next, min := -1, 0.0
+			for j := 0; j < inst.Size; j++ {
+				if visited[j] || load+inst.Demands[j] > inst.Capacity {
+					continue
+				}
+				if d := inst.Dist[state*inst.Size+j]; next == -1 || d < min {
+					next, min = j, d
+				}
+			}
+			if next == -1 {
+				break
+			}
+			visited[next] = true
+			load += inst.Demands[next]
+			route = append(route, next)
+			state = next
+		}
+		route = append(route, inst.Depot)
+		cost, route := TwoOpt(inst.Dist, inst.Size, route)
+		routes = append(routes, Tour{
+			Cost:  cost,
+			Route: route,
+		})
+	}
+	routes = CrossRouteOrOpt(inst, routes)
+	var unserved []int
+	for city, served := range visited {
+		if !served {
+			unserved = append(unserved, city)
+		}
+	}
+	if len(unserved) > 0 {
+		return routes, fmt.Errorf("cities %v are unserved by %d vehicles of capacity %f",
+			unserved, inst.Vehicles, inst.Capacity)
+	}
+	return routes, nil
+}
+
+// CrossRouteOrOpt repeatedly moves the single city between two routes that
+// reduces the total cost the most without exceeding the capacity of a vehicle
+func CrossRouteOrOpt(inst VRPInstance, routes []Tour) []Tour {
+	result := make([]Tour, len(routes))
+	for i, route := range routes {
+		result[i] = Tour{
+			Cost:  TourCost(inst.Dist, inst.Size, route.Route),
+			Route: append([]int{}, route.Route...),
+		}
+	}
+	d := func(a, b int) float64 {
+		return inst.Dist[a*inst.Size+b]
+	}
+	for {
+		best, bi, bk, bj, bp := 0.0, -1, 0, 0, 0
+		for i, from := range result {
+			r := from.Route
+			for k := 1; k < len(r)-1; k++ {
+				city := r[k]
+				removed := d(r[k-1], r[k+1]) - d(r[k-1], city) - d(city, r[k+1])
+				for j, to := range result {
+					if i == j || inst.load(to.Route)+inst.Demands[city] > inst.Capacity {
+						continue
+					}
+					s := to.Route
+					for p := 1; p < len(s); p++ {
+						added := d(s[p-1], city) + d(city, s[p]) - d(s[p-1], s[p])
+						if delta := removed + added; delta < best-1e-12 {
+							best, bi, bk, bj, bp = delta, i, k, j, p
+						}
+					}
+				}
+			}
+		}
+		if bi == -1 {
+			return result
+		}
+		from, to := result[bi].Route, result[bj].Route
+		city := from[bk]
+		from = append(from[:bk:bk], from[bk+1:]...)
+		to = append(to[:bp:bp], append([]int{city}, to[bp:]...)...)
+		result[bi] = Tour{Cost: TourCost(inst.Dist, inst.Size, from), Route: from}
+		result[bj] = Tour{Cost: TourCost(inst.Dist, inst.Size, to), Route: to}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCrossRouteOrOpt(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inst := VRPInstance{
+		Dist:     euclideanInstance(rng, 10),
+		Size:     10,
+		Depot:    0,
+		Demands:  []float64{0, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		Capacity: 4,
+		Vehicles: 3,
+	}
+	// assign the cities to the vehicles in turn and optimize each route
+	routes := make([]Tour, inst.Vehicles)
+	for v := range routes {
+		route := []int{inst.Depot}
+		for city := 1 + v; city < inst.Size; city += inst.Vehicles {
+			route = append(route, city)
+		}
+		cost, route := TwoOpt(inst.Dist, inst.Size, append(route, inst.Depot))
+		routes[v] = Tour{Cost: cost, Route: route}
+	}
+	independent := inst.routesCost(routes)
+	improved := CrossRouteOrOpt(inst, routes)
+	if cost := inst.routesCost(improved); cost >= independent {
+		t.Errorf("Expected cross route moves to improve on %f, got %f", independent, cost)
+	}
+	visited := make([]int, inst.Size)
+	for _, route := range improved {
+		if load := inst.load(route.Route); load > inst.Capacity {
+			t.Errorf("Route %v exceeds capacity with load %f", route.Route, load)
+		}
+		for _, city := range route.Route[1 : len(route.Route)-1] {
+			visited[city]++
+		}
+	}
+	for city, count := range visited[1:] {
+		if count != 1 {
+			t.Errorf("Expected city %d to be visited once, got %d", city+1, count)
+		}
+	}
+	routes, err := SolveVRP(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != inst.Vehicles {
+		t.Errorf("Expected %d routes, got %d", inst.Vehicles, len(routes))
+	}
+}
+
+func TestSolveVRPUnserved(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inst := VRPInstance{
+		Dist:     euclideanInstance(rng, 10),
+		Size:     10,
+		Depot:    0,
+		Demands:  []float64{0, 1, 1, 1, 1, 1, 1, 1, 1, 5},
+		Capacity: 4,
+		Vehicles: 2,
+	}
+	routes, err := SolveVRP(inst)
+	if err == nil {
+		t.Fatal("Expected an error for demand exceeding the vehicles")
+	}
+	served := 0
+	for _, route := range routes {
+		served += len(route.Route) - 2
+	}
+	if served != 8 {
+		t.Errorf("Expected 8 cities to be served, got %d", served)
+	}
+}
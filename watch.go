@@ -0,0 +1,94 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/fs"
+	"os"
+	"time"
+)
+
+// WatchInterval is how often -watch checks the input file for changes
+const WatchInterval = 250 * time.Millisecond
+
+// WatchRetries is the number of checks in a row that can fail to stat the
+// file before Watch gives up, editors that save by renaming a temporary file
+// over the file leave it missing for a moment
+const WatchRetries = 20
+
+// watcher detects modifications of a file by polling its modification time
+// and size
+type watcher struct {
+	path    string
+	stat    func(name string) (fs.FileInfo, error)
+	modTime time.Time
+	size    int64
+	// failures is the number of checks in a row that failed to stat the file
+	failures int
+}
+
+// newWatcher starts watching the file at path with stat
+func newWatcher(path string, stat func(name string) (fs.FileInfo, error)) (*watcher, error) {
+	w := &watcher{path: path, stat: stat}
+	info, err := stat(path)
+	if err != nil {
+		return nil, err
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+	return w, nil
+}
+
+// changed reports whether the file was modified since the last call. A
+// failure to stat the file counts as not changed until WatchRetries checks in
+// a row have failed.
+func (w *watcher) changed() (bool, error) {
+	info, err := w.stat(w.path)
+	if err != nil {
+		w.failures++
+		if w.failures >= WatchRetries {
+			return false, err
+		}
+		return false, nil
+	}
+	w.failures = 0
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false, nil
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+	return true, nil
+}
+
+// Watch calls solve each time the file at path is modified, checking the file
+// every interval. The file is polled rather than watched with fsnotify, which
+// isn't a dependency, and polling also sees a file replaced by a rename. It
+// returns when done is closed, or with an error when the file can't be read
+// at the start or for WatchRetries checks in a row.
+func Watch(path string, interval time.Duration, done <-chan struct{}, solve func()) error {
+	w, err := newWatcher(path, os.Stat)
+	if err != nil {
+		return err
+	}
+	return w.watch(interval, done, solve)
+}
+
+// watch calls solve each time the file is modified until done is closed
+func (w *watcher) watch(interval time.Duration, done <-chan struct{}, solve func()) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ticker.C:
+			changed, err := w.changed()
+			if err != nil {
+				return err
+			}
+			if changed {
+				solve()
+			}
+		}
+	}
+}
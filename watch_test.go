@@ -0,0 +1,106 @@
+// Copyright 2022 The Salesman Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+)
+
+// fakeFileInfo is the FileInfo of a file in a fake filesystem
+type fakeFileInfo struct {
+	modTime time.Time
+	size    int64
+}
+
+func (f fakeFileInfo) Name() string       { return "instance.csv" }
+func (f fakeFileInfo) Size() int64        { return f.size }
+func (f fakeFileInfo) Mode() fs.FileMode  { return 0644 }
+func (f fakeFileInfo) ModTime() time.Time { return f.modTime }
+func (f fakeFileInfo) IsDir() bool        { return false }
+func (f fakeFileInfo) Sys() interface{}   { return nil }
+
+func TestWatch(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	// the file is written twice, the second write keeps the modification
+	// time but changes the size
+	infos := []fakeFileInfo{
+		{start, 10},
+		{start, 10},
+		{start.Add(time.Second), 10},
+		{start.Add(time.Second), 10},
+		{start.Add(time.Second), 10},
+		{start.Add(time.Second), 12},
+		{start.Add(time.Second), 12},
+	}
+	// the watcher can check again before it sees done is closed, so the last
+	// info is repeated after the list is exhausted
+	done := make(chan struct{})
+	calls := 0
+	stat := func(name string) (fs.FileInfo, error) {
+		info := infos[len(infos)-1]
+		if calls < len(infos) {
+			info = infos[calls]
+		}
+		calls++
+		if calls == len(infos) {
+			close(done)
+		}
+		return info, nil
+	}
+	w, err := newWatcher("instance.csv", stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solves := 0
+	err = w.watch(time.Millisecond, done, func() {
+		solves++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solves != 2 {
+		t.Errorf("Expected 2 solves for 2 changes, got %d", solves)
+	}
+	if _, err := newWatcher("missing.csv", func(name string) (fs.FileInfo, error) {
+		return nil, fs.ErrNotExist
+	}); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestWatchMissing(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	// the file is saved by renaming a temporary file over it, so it is
+	// missing for a few checks, and then it is deleted
+	infos := []fs.FileInfo{fakeFileInfo{start, 10}, nil, nil, nil, fakeFileInfo{start.Add(time.Second), 11}}
+	for i := 0; i < WatchRetries; i++ {
+		infos = append(infos, nil)
+	}
+	calls := 0
+	stat := func(name string) (fs.FileInfo, error) {
+		info := infos[calls]
+		calls++
+		if info == nil {
+			return nil, fs.ErrNotExist
+		}
+		return info, nil
+	}
+	w, err := newWatcher("instance.csv", stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solves := 0
+	err = w.watch(time.Millisecond, nil, func() {
+		solves++
+	})
+	if err == nil || calls != len(infos) {
+		t.Errorf("Expected an error after %d failed checks, got %v after %d", WatchRetries, err, calls-5)
+	}
+	if solves != 1 {
+		t.Errorf("Expected 1 solve for the renamed file, got %d", solves)
+	}
+}